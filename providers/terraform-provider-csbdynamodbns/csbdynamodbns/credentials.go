@@ -0,0 +1,73 @@
+package csbdynamodbns
+
+import (
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/dynaclient"
+)
+
+const (
+	AwsAccessKeyIDKey     = "access_key_id"
+	AwsSecretAccessKeyKey = "secret_access_key"
+	AwsSessionTokenKey    = "session_token"
+	AssumeRoleARNKey      = "assume_role_arn"
+	ExternalIDKey         = "external_id"
+	RoleSessionNameKey    = "role_session_name"
+)
+
+// assumeRoleSchema is shared by the provider, which sets the defaults, and the resources, which can override them
+func assumeRoleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		AssumeRoleARNKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ARN of a role to assume using the static credentials",
+		},
+		ExternalIDKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "External ID required by the trust policy of the role",
+		},
+		RoleSessionNameKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Session name used when assuming the role",
+		},
+	}
+}
+
+// withCredentialsSchema adds the credentials used to connect to DynamoDB to the schema of a resource or data source
+func withCredentialsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	maps.Copy(s, assumeRoleSchema())
+	maps.Copy(s, map[string]*schema.Schema{
+		AwsAccessKeyIDKey: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		AwsSecretAccessKeyKey: {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		AwsSessionTokenKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Session token for temporary credentials",
+		},
+	})
+	return s
+}
+
+func credentialsFromResourceData(data *schema.ResourceData) dynaclient.Credentials {
+	return dynaclient.Credentials{
+		AccessKeyID:     data.Get(AwsAccessKeyIDKey).(string),
+		SecretAccessKey: data.Get(AwsSecretAccessKeyKey).(string),
+		SessionToken:    data.Get(AwsSessionTokenKey).(string),
+		AssumeRoleARN:   data.Get(AssumeRoleARNKey).(string),
+		ExternalID:      data.Get(ExternalIDKey).(string),
+		RoleSessionName: data.Get(RoleSessionNameKey).(string),
+	}
+}
@@ -12,6 +12,36 @@ import (
 )
 
 type FakeDynamoDBClient struct {
+	CreateBackupStub        func(context.Context, *dynamodb.CreateBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.CreateBackupOutput, error)
+	createBackupMutex       sync.RWMutex
+	createBackupArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.CreateBackupInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	createBackupReturns struct {
+		result1 *dynamodb.CreateBackupOutput
+		result2 error
+	}
+	createBackupReturnsOnCall map[int]struct {
+		result1 *dynamodb.CreateBackupOutput
+		result2 error
+	}
+	DeleteBackupStub        func(context.Context, *dynamodb.DeleteBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.DeleteBackupOutput, error)
+	deleteBackupMutex       sync.RWMutex
+	deleteBackupArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.DeleteBackupInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	deleteBackupReturns struct {
+		result1 *dynamodb.DeleteBackupOutput
+		result2 error
+	}
+	deleteBackupReturnsOnCall map[int]struct {
+		result1 *dynamodb.DeleteBackupOutput
+		result2 error
+	}
 	DeleteTableStub        func(context.Context, *dynamodb.DeleteTableInput, ...func(options *dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
 	deleteTableMutex       sync.RWMutex
 	deleteTableArgsForCall []struct {
@@ -27,6 +57,66 @@ type FakeDynamoDBClient struct {
 		result1 *dynamodb.DeleteTableOutput
 		result2 error
 	}
+	DescribeKinesisStreamingDestinationStub        func(context.Context, *dynamodb.DescribeKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DescribeKinesisStreamingDestinationOutput, error)
+	describeKinesisStreamingDestinationMutex       sync.RWMutex
+	describeKinesisStreamingDestinationArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.DescribeKinesisStreamingDestinationInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	describeKinesisStreamingDestinationReturns struct {
+		result1 *dynamodb.DescribeKinesisStreamingDestinationOutput
+		result2 error
+	}
+	describeKinesisStreamingDestinationReturnsOnCall map[int]struct {
+		result1 *dynamodb.DescribeKinesisStreamingDestinationOutput
+		result2 error
+	}
+	DescribeTableStub        func(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	describeTableMutex       sync.RWMutex
+	describeTableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.DescribeTableInput
+		arg3 []func(*dynamodb.Options)
+	}
+	describeTableReturns struct {
+		result1 *dynamodb.DescribeTableOutput
+		result2 error
+	}
+	describeTableReturnsOnCall map[int]struct {
+		result1 *dynamodb.DescribeTableOutput
+		result2 error
+	}
+	DisableKinesisStreamingDestinationStub        func(context.Context, *dynamodb.DisableKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DisableKinesisStreamingDestinationOutput, error)
+	disableKinesisStreamingDestinationMutex       sync.RWMutex
+	disableKinesisStreamingDestinationArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.DisableKinesisStreamingDestinationInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	disableKinesisStreamingDestinationReturns struct {
+		result1 *dynamodb.DisableKinesisStreamingDestinationOutput
+		result2 error
+	}
+	disableKinesisStreamingDestinationReturnsOnCall map[int]struct {
+		result1 *dynamodb.DisableKinesisStreamingDestinationOutput
+		result2 error
+	}
+	ListBackupsStub        func(context.Context, *dynamodb.ListBackupsInput, ...func(options *dynamodb.Options)) (*dynamodb.ListBackupsOutput, error)
+	listBackupsMutex       sync.RWMutex
+	listBackupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.ListBackupsInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	listBackupsReturns struct {
+		result1 *dynamodb.ListBackupsOutput
+		result2 error
+	}
+	listBackupsReturnsOnCall map[int]struct {
+		result1 *dynamodb.ListBackupsOutput
+		result2 error
+	}
 	ListTablesStub        func(context.Context, *dynamodb.ListTablesInput, ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
 	listTablesMutex       sync.RWMutex
 	listTablesArgsForCall []struct {
@@ -42,10 +132,157 @@ type FakeDynamoDBClient struct {
 		result1 *dynamodb.ListTablesOutput
 		result2 error
 	}
+	ListTagsOfResourceStub        func(context.Context, *dynamodb.ListTagsOfResourceInput, ...func(options *dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)
+	listTagsOfResourceMutex       sync.RWMutex
+	listTagsOfResourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 *dynamodb.ListTagsOfResourceInput
+		arg3 []func(options *dynamodb.Options)
+	}
+	listTagsOfResourceReturns struct {
+		result1 *dynamodb.ListTagsOfResourceOutput
+		result2 error
+	}
+	listTagsOfResourceReturnsOnCall map[int]struct {
+		result1 *dynamodb.ListTagsOfResourceOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeDynamoDBClient) CreateBackup(arg1 context.Context, arg2 *dynamodb.CreateBackupInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.CreateBackupOutput, error) {
+	fake.createBackupMutex.Lock()
+	ret, specificReturn := fake.createBackupReturnsOnCall[len(fake.createBackupArgsForCall)]
+	fake.createBackupArgsForCall = append(fake.createBackupArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.CreateBackupInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateBackupStub
+	fakeReturns := fake.createBackupReturns
+	fake.recordInvocation("CreateBackup", []interface{}{arg1, arg2, arg3})
+	fake.createBackupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) CreateBackupCallCount() int {
+	fake.createBackupMutex.RLock()
+	defer fake.createBackupMutex.RUnlock()
+	return len(fake.createBackupArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) CreateBackupCalls(stub func(context.Context, *dynamodb.CreateBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.CreateBackupOutput, error)) {
+	fake.createBackupMutex.Lock()
+	defer fake.createBackupMutex.Unlock()
+	fake.CreateBackupStub = stub
+}
+
+func (fake *FakeDynamoDBClient) CreateBackupArgsForCall(i int) (context.Context, *dynamodb.CreateBackupInput, []func(options *dynamodb.Options)) {
+	fake.createBackupMutex.RLock()
+	defer fake.createBackupMutex.RUnlock()
+	argsForCall := fake.createBackupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) CreateBackupReturns(result1 *dynamodb.CreateBackupOutput, result2 error) {
+	fake.createBackupMutex.Lock()
+	defer fake.createBackupMutex.Unlock()
+	fake.CreateBackupStub = nil
+	fake.createBackupReturns = struct {
+		result1 *dynamodb.CreateBackupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) CreateBackupReturnsOnCall(i int, result1 *dynamodb.CreateBackupOutput, result2 error) {
+	fake.createBackupMutex.Lock()
+	defer fake.createBackupMutex.Unlock()
+	fake.CreateBackupStub = nil
+	if fake.createBackupReturnsOnCall == nil {
+		fake.createBackupReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.CreateBackupOutput
+			result2 error
+		})
+	}
+	fake.createBackupReturnsOnCall[i] = struct {
+		result1 *dynamodb.CreateBackupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackup(arg1 context.Context, arg2 *dynamodb.DeleteBackupInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.DeleteBackupOutput, error) {
+	fake.deleteBackupMutex.Lock()
+	ret, specificReturn := fake.deleteBackupReturnsOnCall[len(fake.deleteBackupArgsForCall)]
+	fake.deleteBackupArgsForCall = append(fake.deleteBackupArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.DeleteBackupInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteBackupStub
+	fakeReturns := fake.deleteBackupReturns
+	fake.recordInvocation("DeleteBackup", []interface{}{arg1, arg2, arg3})
+	fake.deleteBackupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackupCallCount() int {
+	fake.deleteBackupMutex.RLock()
+	defer fake.deleteBackupMutex.RUnlock()
+	return len(fake.deleteBackupArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackupCalls(stub func(context.Context, *dynamodb.DeleteBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.DeleteBackupOutput, error)) {
+	fake.deleteBackupMutex.Lock()
+	defer fake.deleteBackupMutex.Unlock()
+	fake.DeleteBackupStub = stub
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackupArgsForCall(i int) (context.Context, *dynamodb.DeleteBackupInput, []func(options *dynamodb.Options)) {
+	fake.deleteBackupMutex.RLock()
+	defer fake.deleteBackupMutex.RUnlock()
+	argsForCall := fake.deleteBackupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackupReturns(result1 *dynamodb.DeleteBackupOutput, result2 error) {
+	fake.deleteBackupMutex.Lock()
+	defer fake.deleteBackupMutex.Unlock()
+	fake.DeleteBackupStub = nil
+	fake.deleteBackupReturns = struct {
+		result1 *dynamodb.DeleteBackupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DeleteBackupReturnsOnCall(i int, result1 *dynamodb.DeleteBackupOutput, result2 error) {
+	fake.deleteBackupMutex.Lock()
+	defer fake.deleteBackupMutex.Unlock()
+	fake.DeleteBackupStub = nil
+	if fake.deleteBackupReturnsOnCall == nil {
+		fake.deleteBackupReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.DeleteBackupOutput
+			result2 error
+		})
+	}
+	fake.deleteBackupReturnsOnCall[i] = struct {
+		result1 *dynamodb.DeleteBackupOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDynamoDBClient) DeleteTable(arg1 context.Context, arg2 *dynamodb.DeleteTableInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.DeleteTableOutput, error) {
 	fake.deleteTableMutex.Lock()
 	ret, specificReturn := fake.deleteTableReturnsOnCall[len(fake.deleteTableArgsForCall)]
@@ -112,6 +349,270 @@ func (fake *FakeDynamoDBClient) DeleteTableReturnsOnCall(i int, result1 *dynamod
 	}{result1, result2}
 }
 
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestination(arg1 context.Context, arg2 *dynamodb.DescribeKinesisStreamingDestinationInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.DescribeKinesisStreamingDestinationOutput, error) {
+	fake.describeKinesisStreamingDestinationMutex.Lock()
+	ret, specificReturn := fake.describeKinesisStreamingDestinationReturnsOnCall[len(fake.describeKinesisStreamingDestinationArgsForCall)]
+	fake.describeKinesisStreamingDestinationArgsForCall = append(fake.describeKinesisStreamingDestinationArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.DescribeKinesisStreamingDestinationInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeKinesisStreamingDestinationStub
+	fakeReturns := fake.describeKinesisStreamingDestinationReturns
+	fake.recordInvocation("DescribeKinesisStreamingDestination", []interface{}{arg1, arg2, arg3})
+	fake.describeKinesisStreamingDestinationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestinationCallCount() int {
+	fake.describeKinesisStreamingDestinationMutex.RLock()
+	defer fake.describeKinesisStreamingDestinationMutex.RUnlock()
+	return len(fake.describeKinesisStreamingDestinationArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestinationCalls(stub func(context.Context, *dynamodb.DescribeKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DescribeKinesisStreamingDestinationOutput, error)) {
+	fake.describeKinesisStreamingDestinationMutex.Lock()
+	defer fake.describeKinesisStreamingDestinationMutex.Unlock()
+	fake.DescribeKinesisStreamingDestinationStub = stub
+}
+
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestinationArgsForCall(i int) (context.Context, *dynamodb.DescribeKinesisStreamingDestinationInput, []func(options *dynamodb.Options)) {
+	fake.describeKinesisStreamingDestinationMutex.RLock()
+	defer fake.describeKinesisStreamingDestinationMutex.RUnlock()
+	argsForCall := fake.describeKinesisStreamingDestinationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestinationReturns(result1 *dynamodb.DescribeKinesisStreamingDestinationOutput, result2 error) {
+	fake.describeKinesisStreamingDestinationMutex.Lock()
+	defer fake.describeKinesisStreamingDestinationMutex.Unlock()
+	fake.DescribeKinesisStreamingDestinationStub = nil
+	fake.describeKinesisStreamingDestinationReturns = struct {
+		result1 *dynamodb.DescribeKinesisStreamingDestinationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DescribeKinesisStreamingDestinationReturnsOnCall(i int, result1 *dynamodb.DescribeKinesisStreamingDestinationOutput, result2 error) {
+	fake.describeKinesisStreamingDestinationMutex.Lock()
+	defer fake.describeKinesisStreamingDestinationMutex.Unlock()
+	fake.DescribeKinesisStreamingDestinationStub = nil
+	if fake.describeKinesisStreamingDestinationReturnsOnCall == nil {
+		fake.describeKinesisStreamingDestinationReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.DescribeKinesisStreamingDestinationOutput
+			result2 error
+		})
+	}
+	fake.describeKinesisStreamingDestinationReturnsOnCall[i] = struct {
+		result1 *dynamodb.DescribeKinesisStreamingDestinationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DescribeTable(arg1 context.Context, arg2 *dynamodb.DescribeTableInput, arg3 ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	fake.describeTableMutex.Lock()
+	ret, specificReturn := fake.describeTableReturnsOnCall[len(fake.describeTableArgsForCall)]
+	fake.describeTableArgsForCall = append(fake.describeTableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.DescribeTableInput
+		arg3 []func(*dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeTableStub
+	fakeReturns := fake.describeTableReturns
+	fake.recordInvocation("DescribeTable", []interface{}{arg1, arg2, arg3})
+	fake.describeTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) DescribeTableCallCount() int {
+	fake.describeTableMutex.RLock()
+	defer fake.describeTableMutex.RUnlock()
+	return len(fake.describeTableArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) DescribeTableCalls(stub func(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)) {
+	fake.describeTableMutex.Lock()
+	defer fake.describeTableMutex.Unlock()
+	fake.DescribeTableStub = stub
+}
+
+func (fake *FakeDynamoDBClient) DescribeTableArgsForCall(i int) (context.Context, *dynamodb.DescribeTableInput, []func(*dynamodb.Options)) {
+	fake.describeTableMutex.RLock()
+	defer fake.describeTableMutex.RUnlock()
+	argsForCall := fake.describeTableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) DescribeTableReturns(result1 *dynamodb.DescribeTableOutput, result2 error) {
+	fake.describeTableMutex.Lock()
+	defer fake.describeTableMutex.Unlock()
+	fake.DescribeTableStub = nil
+	fake.describeTableReturns = struct {
+		result1 *dynamodb.DescribeTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DescribeTableReturnsOnCall(i int, result1 *dynamodb.DescribeTableOutput, result2 error) {
+	fake.describeTableMutex.Lock()
+	defer fake.describeTableMutex.Unlock()
+	fake.DescribeTableStub = nil
+	if fake.describeTableReturnsOnCall == nil {
+		fake.describeTableReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.DescribeTableOutput
+			result2 error
+		})
+	}
+	fake.describeTableReturnsOnCall[i] = struct {
+		result1 *dynamodb.DescribeTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestination(arg1 context.Context, arg2 *dynamodb.DisableKinesisStreamingDestinationInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.DisableKinesisStreamingDestinationOutput, error) {
+	fake.disableKinesisStreamingDestinationMutex.Lock()
+	ret, specificReturn := fake.disableKinesisStreamingDestinationReturnsOnCall[len(fake.disableKinesisStreamingDestinationArgsForCall)]
+	fake.disableKinesisStreamingDestinationArgsForCall = append(fake.disableKinesisStreamingDestinationArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.DisableKinesisStreamingDestinationInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DisableKinesisStreamingDestinationStub
+	fakeReturns := fake.disableKinesisStreamingDestinationReturns
+	fake.recordInvocation("DisableKinesisStreamingDestination", []interface{}{arg1, arg2, arg3})
+	fake.disableKinesisStreamingDestinationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestinationCallCount() int {
+	fake.disableKinesisStreamingDestinationMutex.RLock()
+	defer fake.disableKinesisStreamingDestinationMutex.RUnlock()
+	return len(fake.disableKinesisStreamingDestinationArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestinationCalls(stub func(context.Context, *dynamodb.DisableKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DisableKinesisStreamingDestinationOutput, error)) {
+	fake.disableKinesisStreamingDestinationMutex.Lock()
+	defer fake.disableKinesisStreamingDestinationMutex.Unlock()
+	fake.DisableKinesisStreamingDestinationStub = stub
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestinationArgsForCall(i int) (context.Context, *dynamodb.DisableKinesisStreamingDestinationInput, []func(options *dynamodb.Options)) {
+	fake.disableKinesisStreamingDestinationMutex.RLock()
+	defer fake.disableKinesisStreamingDestinationMutex.RUnlock()
+	argsForCall := fake.disableKinesisStreamingDestinationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestinationReturns(result1 *dynamodb.DisableKinesisStreamingDestinationOutput, result2 error) {
+	fake.disableKinesisStreamingDestinationMutex.Lock()
+	defer fake.disableKinesisStreamingDestinationMutex.Unlock()
+	fake.DisableKinesisStreamingDestinationStub = nil
+	fake.disableKinesisStreamingDestinationReturns = struct {
+		result1 *dynamodb.DisableKinesisStreamingDestinationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) DisableKinesisStreamingDestinationReturnsOnCall(i int, result1 *dynamodb.DisableKinesisStreamingDestinationOutput, result2 error) {
+	fake.disableKinesisStreamingDestinationMutex.Lock()
+	defer fake.disableKinesisStreamingDestinationMutex.Unlock()
+	fake.DisableKinesisStreamingDestinationStub = nil
+	if fake.disableKinesisStreamingDestinationReturnsOnCall == nil {
+		fake.disableKinesisStreamingDestinationReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.DisableKinesisStreamingDestinationOutput
+			result2 error
+		})
+	}
+	fake.disableKinesisStreamingDestinationReturnsOnCall[i] = struct {
+		result1 *dynamodb.DisableKinesisStreamingDestinationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) ListBackups(arg1 context.Context, arg2 *dynamodb.ListBackupsInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.ListBackupsOutput, error) {
+	fake.listBackupsMutex.Lock()
+	ret, specificReturn := fake.listBackupsReturnsOnCall[len(fake.listBackupsArgsForCall)]
+	fake.listBackupsArgsForCall = append(fake.listBackupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.ListBackupsInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ListBackupsStub
+	fakeReturns := fake.listBackupsReturns
+	fake.recordInvocation("ListBackups", []interface{}{arg1, arg2, arg3})
+	fake.listBackupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) ListBackupsCallCount() int {
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
+	return len(fake.listBackupsArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) ListBackupsCalls(stub func(context.Context, *dynamodb.ListBackupsInput, ...func(options *dynamodb.Options)) (*dynamodb.ListBackupsOutput, error)) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = stub
+}
+
+func (fake *FakeDynamoDBClient) ListBackupsArgsForCall(i int) (context.Context, *dynamodb.ListBackupsInput, []func(options *dynamodb.Options)) {
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
+	argsForCall := fake.listBackupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) ListBackupsReturns(result1 *dynamodb.ListBackupsOutput, result2 error) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = nil
+	fake.listBackupsReturns = struct {
+		result1 *dynamodb.ListBackupsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) ListBackupsReturnsOnCall(i int, result1 *dynamodb.ListBackupsOutput, result2 error) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = nil
+	if fake.listBackupsReturnsOnCall == nil {
+		fake.listBackupsReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.ListBackupsOutput
+			result2 error
+		})
+	}
+	fake.listBackupsReturnsOnCall[i] = struct {
+		result1 *dynamodb.ListBackupsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDynamoDBClient) ListTables(arg1 context.Context, arg2 *dynamodb.ListTablesInput, arg3 ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
 	fake.listTablesMutex.Lock()
 	ret, specificReturn := fake.listTablesReturnsOnCall[len(fake.listTablesArgsForCall)]
@@ -178,13 +679,93 @@ func (fake *FakeDynamoDBClient) ListTablesReturnsOnCall(i int, result1 *dynamodb
 	}{result1, result2}
 }
 
+func (fake *FakeDynamoDBClient) ListTagsOfResource(arg1 context.Context, arg2 *dynamodb.ListTagsOfResourceInput, arg3 ...func(options *dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+	fake.listTagsOfResourceMutex.Lock()
+	ret, specificReturn := fake.listTagsOfResourceReturnsOnCall[len(fake.listTagsOfResourceArgsForCall)]
+	fake.listTagsOfResourceArgsForCall = append(fake.listTagsOfResourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 *dynamodb.ListTagsOfResourceInput
+		arg3 []func(options *dynamodb.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ListTagsOfResourceStub
+	fakeReturns := fake.listTagsOfResourceReturns
+	fake.recordInvocation("ListTagsOfResource", []interface{}{arg1, arg2, arg3})
+	fake.listTagsOfResourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBClient) ListTagsOfResourceCallCount() int {
+	fake.listTagsOfResourceMutex.RLock()
+	defer fake.listTagsOfResourceMutex.RUnlock()
+	return len(fake.listTagsOfResourceArgsForCall)
+}
+
+func (fake *FakeDynamoDBClient) ListTagsOfResourceCalls(stub func(context.Context, *dynamodb.ListTagsOfResourceInput, ...func(options *dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)) {
+	fake.listTagsOfResourceMutex.Lock()
+	defer fake.listTagsOfResourceMutex.Unlock()
+	fake.ListTagsOfResourceStub = stub
+}
+
+func (fake *FakeDynamoDBClient) ListTagsOfResourceArgsForCall(i int) (context.Context, *dynamodb.ListTagsOfResourceInput, []func(options *dynamodb.Options)) {
+	fake.listTagsOfResourceMutex.RLock()
+	defer fake.listTagsOfResourceMutex.RUnlock()
+	argsForCall := fake.listTagsOfResourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBClient) ListTagsOfResourceReturns(result1 *dynamodb.ListTagsOfResourceOutput, result2 error) {
+	fake.listTagsOfResourceMutex.Lock()
+	defer fake.listTagsOfResourceMutex.Unlock()
+	fake.ListTagsOfResourceStub = nil
+	fake.listTagsOfResourceReturns = struct {
+		result1 *dynamodb.ListTagsOfResourceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBClient) ListTagsOfResourceReturnsOnCall(i int, result1 *dynamodb.ListTagsOfResourceOutput, result2 error) {
+	fake.listTagsOfResourceMutex.Lock()
+	defer fake.listTagsOfResourceMutex.Unlock()
+	fake.ListTagsOfResourceStub = nil
+	if fake.listTagsOfResourceReturnsOnCall == nil {
+		fake.listTagsOfResourceReturnsOnCall = make(map[int]struct {
+			result1 *dynamodb.ListTagsOfResourceOutput
+			result2 error
+		})
+	}
+	fake.listTagsOfResourceReturnsOnCall[i] = struct {
+		result1 *dynamodb.ListTagsOfResourceOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDynamoDBClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createBackupMutex.RLock()
+	defer fake.createBackupMutex.RUnlock()
+	fake.deleteBackupMutex.RLock()
+	defer fake.deleteBackupMutex.RUnlock()
 	fake.deleteTableMutex.RLock()
 	defer fake.deleteTableMutex.RUnlock()
+	fake.describeKinesisStreamingDestinationMutex.RLock()
+	defer fake.describeKinesisStreamingDestinationMutex.RUnlock()
+	fake.describeTableMutex.RLock()
+	defer fake.describeTableMutex.RUnlock()
+	fake.disableKinesisStreamingDestinationMutex.RLock()
+	defer fake.disableKinesisStreamingDestinationMutex.RUnlock()
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
 	fake.listTablesMutex.RLock()
 	defer fake.listTablesMutex.RUnlock()
+	fake.listTagsOfResourceMutex.RLock()
+	defer fake.listTagsOfResourceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
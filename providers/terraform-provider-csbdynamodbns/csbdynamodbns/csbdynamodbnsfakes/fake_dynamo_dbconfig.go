@@ -8,15 +8,15 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/csbdynamodbns"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/dynaclient"
 )
 
 type FakeDynamoDBConfig struct {
-	GetClientStub        func(context.Context, string, string) (csbdynamodbns.DynamoDBClient, error)
+	GetClientStub        func(context.Context, dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error)
 	getClientMutex       sync.RWMutex
 	getClientArgsForCall []struct {
 		arg1 context.Context
-		arg2 string
-		arg3 string
+		arg2 dynaclient.Credentials
 	}
 	getClientReturns struct {
 		result1 csbdynamodbns.DynamoDBClient
@@ -26,6 +26,16 @@ type FakeDynamoDBConfig struct {
 		result1 csbdynamodbns.DynamoDBClient
 		result2 error
 	}
+	GetInstanceIDStub        func() string
+	getInstanceIDMutex       sync.RWMutex
+	getInstanceIDArgsForCall []struct {
+	}
+	getInstanceIDReturns struct {
+		result1 string
+	}
+	getInstanceIDReturnsOnCall map[int]struct {
+		result1 string
+	}
 	GetPrefixStub        func() string
 	getPrefixMutex       sync.RWMutex
 	getPrefixArgsForCall []struct {
@@ -36,24 +46,48 @@ type FakeDynamoDBConfig struct {
 	getPrefixReturnsOnCall map[int]struct {
 		result1 string
 	}
+	GetRegionalClientStub        func(context.Context, string, dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error)
+	getRegionalClientMutex       sync.RWMutex
+	getRegionalClientArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 dynaclient.Credentials
+	}
+	getRegionalClientReturns struct {
+		result1 csbdynamodbns.DynamoDBClient
+		result2 error
+	}
+	getRegionalClientReturnsOnCall map[int]struct {
+		result1 csbdynamodbns.DynamoDBClient
+		result2 error
+	}
+	IsStrictPrefixMatchStub        func() bool
+	isStrictPrefixMatchMutex       sync.RWMutex
+	isStrictPrefixMatchArgsForCall []struct {
+	}
+	isStrictPrefixMatchReturns struct {
+		result1 bool
+	}
+	isStrictPrefixMatchReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeDynamoDBConfig) GetClient(arg1 context.Context, arg2 string, arg3 string) (csbdynamodbns.DynamoDBClient, error) {
+func (fake *FakeDynamoDBConfig) GetClient(arg1 context.Context, arg2 dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error) {
 	fake.getClientMutex.Lock()
 	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
 	fake.getClientArgsForCall = append(fake.getClientArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
-		arg3 string
-	}{arg1, arg2, arg3})
+		arg2 dynaclient.Credentials
+	}{arg1, arg2})
 	stub := fake.GetClientStub
 	fakeReturns := fake.getClientReturns
-	fake.recordInvocation("GetClient", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("GetClient", []interface{}{arg1, arg2})
 	fake.getClientMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -67,17 +101,17 @@ func (fake *FakeDynamoDBConfig) GetClientCallCount() int {
 	return len(fake.getClientArgsForCall)
 }
 
-func (fake *FakeDynamoDBConfig) GetClientCalls(stub func(context.Context, string, string) (csbdynamodbns.DynamoDBClient, error)) {
+func (fake *FakeDynamoDBConfig) GetClientCalls(stub func(context.Context, dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error)) {
 	fake.getClientMutex.Lock()
 	defer fake.getClientMutex.Unlock()
 	fake.GetClientStub = stub
 }
 
-func (fake *FakeDynamoDBConfig) GetClientArgsForCall(i int) (context.Context, string, string) {
+func (fake *FakeDynamoDBConfig) GetClientArgsForCall(i int) (context.Context, dynaclient.Credentials) {
 	fake.getClientMutex.RLock()
 	defer fake.getClientMutex.RUnlock()
 	argsForCall := fake.getClientArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeDynamoDBConfig) GetClientReturns(result1 csbdynamodbns.DynamoDBClient, result2 error) {
@@ -106,6 +140,59 @@ func (fake *FakeDynamoDBConfig) GetClientReturnsOnCall(i int, result1 csbdynamod
 	}{result1, result2}
 }
 
+func (fake *FakeDynamoDBConfig) GetInstanceID() string {
+	fake.getInstanceIDMutex.Lock()
+	ret, specificReturn := fake.getInstanceIDReturnsOnCall[len(fake.getInstanceIDArgsForCall)]
+	fake.getInstanceIDArgsForCall = append(fake.getInstanceIDArgsForCall, struct {
+	}{})
+	stub := fake.GetInstanceIDStub
+	fakeReturns := fake.getInstanceIDReturns
+	fake.recordInvocation("GetInstanceID", []interface{}{})
+	fake.getInstanceIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDynamoDBConfig) GetInstanceIDCallCount() int {
+	fake.getInstanceIDMutex.RLock()
+	defer fake.getInstanceIDMutex.RUnlock()
+	return len(fake.getInstanceIDArgsForCall)
+}
+
+func (fake *FakeDynamoDBConfig) GetInstanceIDCalls(stub func() string) {
+	fake.getInstanceIDMutex.Lock()
+	defer fake.getInstanceIDMutex.Unlock()
+	fake.GetInstanceIDStub = stub
+}
+
+func (fake *FakeDynamoDBConfig) GetInstanceIDReturns(result1 string) {
+	fake.getInstanceIDMutex.Lock()
+	defer fake.getInstanceIDMutex.Unlock()
+	fake.GetInstanceIDStub = nil
+	fake.getInstanceIDReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeDynamoDBConfig) GetInstanceIDReturnsOnCall(i int, result1 string) {
+	fake.getInstanceIDMutex.Lock()
+	defer fake.getInstanceIDMutex.Unlock()
+	fake.GetInstanceIDStub = nil
+	if fake.getInstanceIDReturnsOnCall == nil {
+		fake.getInstanceIDReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.getInstanceIDReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeDynamoDBConfig) GetPrefix() string {
 	fake.getPrefixMutex.Lock()
 	ret, specificReturn := fake.getPrefixReturnsOnCall[len(fake.getPrefixArgsForCall)]
@@ -159,13 +246,138 @@ func (fake *FakeDynamoDBConfig) GetPrefixReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeDynamoDBConfig) GetRegionalClient(arg1 context.Context, arg2 string, arg3 dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error) {
+	fake.getRegionalClientMutex.Lock()
+	ret, specificReturn := fake.getRegionalClientReturnsOnCall[len(fake.getRegionalClientArgsForCall)]
+	fake.getRegionalClientArgsForCall = append(fake.getRegionalClientArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 dynaclient.Credentials
+	}{arg1, arg2, arg3})
+	stub := fake.GetRegionalClientStub
+	fakeReturns := fake.getRegionalClientReturns
+	fake.recordInvocation("GetRegionalClient", []interface{}{arg1, arg2, arg3})
+	fake.getRegionalClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDynamoDBConfig) GetRegionalClientCallCount() int {
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	return len(fake.getRegionalClientArgsForCall)
+}
+
+func (fake *FakeDynamoDBConfig) GetRegionalClientCalls(stub func(context.Context, string, dynaclient.Credentials) (csbdynamodbns.DynamoDBClient, error)) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = stub
+}
+
+func (fake *FakeDynamoDBConfig) GetRegionalClientArgsForCall(i int) (context.Context, string, dynaclient.Credentials) {
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	argsForCall := fake.getRegionalClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDynamoDBConfig) GetRegionalClientReturns(result1 csbdynamodbns.DynamoDBClient, result2 error) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = nil
+	fake.getRegionalClientReturns = struct {
+		result1 csbdynamodbns.DynamoDBClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBConfig) GetRegionalClientReturnsOnCall(i int, result1 csbdynamodbns.DynamoDBClient, result2 error) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = nil
+	if fake.getRegionalClientReturnsOnCall == nil {
+		fake.getRegionalClientReturnsOnCall = make(map[int]struct {
+			result1 csbdynamodbns.DynamoDBClient
+			result2 error
+		})
+	}
+	fake.getRegionalClientReturnsOnCall[i] = struct {
+		result1 csbdynamodbns.DynamoDBClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDynamoDBConfig) IsStrictPrefixMatch() bool {
+	fake.isStrictPrefixMatchMutex.Lock()
+	ret, specificReturn := fake.isStrictPrefixMatchReturnsOnCall[len(fake.isStrictPrefixMatchArgsForCall)]
+	fake.isStrictPrefixMatchArgsForCall = append(fake.isStrictPrefixMatchArgsForCall, struct {
+	}{})
+	stub := fake.IsStrictPrefixMatchStub
+	fakeReturns := fake.isStrictPrefixMatchReturns
+	fake.recordInvocation("IsStrictPrefixMatch", []interface{}{})
+	fake.isStrictPrefixMatchMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDynamoDBConfig) IsStrictPrefixMatchCallCount() int {
+	fake.isStrictPrefixMatchMutex.RLock()
+	defer fake.isStrictPrefixMatchMutex.RUnlock()
+	return len(fake.isStrictPrefixMatchArgsForCall)
+}
+
+func (fake *FakeDynamoDBConfig) IsStrictPrefixMatchCalls(stub func() bool) {
+	fake.isStrictPrefixMatchMutex.Lock()
+	defer fake.isStrictPrefixMatchMutex.Unlock()
+	fake.IsStrictPrefixMatchStub = stub
+}
+
+func (fake *FakeDynamoDBConfig) IsStrictPrefixMatchReturns(result1 bool) {
+	fake.isStrictPrefixMatchMutex.Lock()
+	defer fake.isStrictPrefixMatchMutex.Unlock()
+	fake.IsStrictPrefixMatchStub = nil
+	fake.isStrictPrefixMatchReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeDynamoDBConfig) IsStrictPrefixMatchReturnsOnCall(i int, result1 bool) {
+	fake.isStrictPrefixMatchMutex.Lock()
+	defer fake.isStrictPrefixMatchMutex.Unlock()
+	fake.IsStrictPrefixMatchStub = nil
+	if fake.isStrictPrefixMatchReturnsOnCall == nil {
+		fake.isStrictPrefixMatchReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isStrictPrefixMatchReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeDynamoDBConfig) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getClientMutex.RLock()
 	defer fake.getClientMutex.RUnlock()
+	fake.getInstanceIDMutex.RLock()
+	defer fake.getInstanceIDMutex.RUnlock()
 	fake.getPrefixMutex.RLock()
 	defer fake.getPrefixMutex.RUnlock()
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	fake.isStrictPrefixMatchMutex.RLock()
+	defer fake.isStrictPrefixMatchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
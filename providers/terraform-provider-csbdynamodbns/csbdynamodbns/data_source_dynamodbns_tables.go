@@ -0,0 +1,116 @@
+package csbdynamodbns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	TablesKey           = "tables"
+	TableNameKey        = "name"
+	TableARNKey         = "arn"
+	TableItemCountKey   = "item_count"
+	TableSizeBytesKey   = "size_bytes"
+	TableBillingModeKey = "billing_mode"
+)
+
+func DataSourceDynamoDBNSTables() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			TablesKey: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						TableNameKey:        {Type: schema.TypeString, Computed: true},
+						TableARNKey:         {Type: schema.TypeString, Computed: true},
+						TableItemCountKey:   {Type: schema.TypeInt, Computed: true},
+						TableSizeBytesKey:   {Type: schema.TypeInt, Computed: true},
+						TableBillingModeKey: {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		}),
+		ReadContext: DataSourceDynamoDBNSTablesRead,
+		Description: "Lists the DynamoDB tables in the namespace",
+	}
+}
+
+func DataSourceDynamoDBNSTablesRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	settings := config.(DynamoDBConfig)
+	client, err := settings.GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ns := namespaceOf(settings)
+	tableNames, err := listTablesInNamespace(ctx, client, ns)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Like the quota and the housekeeping, the inventory leaves out the tables of other service instances
+	tableNames, err = ns.ownTables(ctx, client, tableNames)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	descriptions, err := describeTables(ctx, client, tableNames)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tables := make([]map[string]any, 0, len(descriptions))
+	for _, table := range descriptions {
+		tables = append(tables, describeTable(table))
+	}
+
+	data.SetId(settings.GetPrefix())
+	if err := data.Set(TablesKey, tables); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// describeTables describes every table, skipping tables that were deleted after they were listed
+func describeTables(ctx context.Context, client dynamodb.DescribeTableAPIClient, tableNames []string) ([]*types.TableDescription, error) {
+	tables := make([]*types.TableDescription, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		output, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+		var notFound *types.ResourceNotFoundException
+		switch {
+		case errors.As(err, &notFound):
+			continue
+		case err != nil:
+			return nil, err
+		case output.Table == nil:
+			return nil, fmt.Errorf("no description returned for table %q", tableName)
+		}
+
+		tables = append(tables, output.Table)
+	}
+	return tables, nil
+}
+
+func describeTable(table *types.TableDescription) map[string]any {
+	// When a table has never been switched to on-demand, DynamoDB omits the billing mode summary
+	billingMode := types.BillingModeProvisioned
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != "" {
+		billingMode = table.BillingModeSummary.BillingMode
+	}
+
+	return map[string]any{
+		TableNameKey:        aws.ToString(table.TableName),
+		TableARNKey:         aws.ToString(table.TableArn),
+		TableItemCountKey:   int(aws.ToInt64(table.ItemCount)),
+		TableSizeBytesKey:   int(aws.ToInt64(table.TableSizeBytes)),
+		TableBillingModeKey: string(billingMode),
+	}
+}
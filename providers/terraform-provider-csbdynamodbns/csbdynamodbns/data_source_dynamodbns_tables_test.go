@@ -0,0 +1,145 @@
+package csbdynamodbns_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/csbdynamodbns"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/csbdynamodbns/csbdynamodbnsfakes"
+)
+
+var _ = Describe("DataSourceDynamoDBNSTables", func() {
+	var (
+		client *csbdynamodbnsfakes.FakeDynamoDBClient
+		config *csbdynamodbnsfakes.FakeDynamoDBConfig
+		data   *schema.ResourceData
+		prefix string
+	)
+
+	BeforeEach(func() {
+		prefix = fmt.Sprintf("csb-%s-", uuid.New())
+
+		client = &csbdynamodbnsfakes.FakeDynamoDBClient{}
+		config = &csbdynamodbnsfakes.FakeDynamoDBConfig{}
+		config.GetClientReturns(client, nil)
+		config.GetPrefixReturns(prefix)
+
+		data = csbdynamodbns.DataSourceDynamoDBNSTables().TestResourceData()
+		Expect(data.Set(csbdynamodbns.AwsAccessKeyIDKey, "id")).NotTo(HaveOccurred())
+		Expect(data.Set(csbdynamodbns.AwsSecretAccessKeyKey, "key")).NotTo(HaveOccurred())
+
+		client.ListTablesReturnsOnCall(0, &dynamodb.ListTablesOutput{
+			TableNames:             []string{prefix + "one", "not-in-the-namespace"},
+			LastEvaluatedTableName: ptr.String("not-in-the-namespace"),
+		}, nil)
+		client.ListTablesReturnsOnCall(1, &dynamodb.ListTablesOutput{
+			TableNames: []string{prefix + "two"},
+		}, nil)
+		client.DescribeTableCalls(func(_ context.Context, input *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+			switch *input.TableName {
+			case prefix + "one":
+				return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+					TableName:          input.TableName,
+					TableArn:           ptr.String("arn:aws:dynamodb:us-west-2:123456789012:table/" + *input.TableName),
+					ItemCount:          ptr.Int64(42),
+					TableSizeBytes:     ptr.Int64(1024),
+					BillingModeSummary: &types.BillingModeSummary{BillingMode: types.BillingModePayPerRequest},
+				}}, nil
+			default:
+				return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+					TableName: input.TableName,
+					TableArn:  ptr.String("arn:aws:dynamodb:us-west-2:123456789012:table/" + *input.TableName),
+				}}, nil
+			}
+		})
+	})
+
+	It("lists the tables in the namespace across all pages", func() {
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d).To(BeNil())
+		Expect(data.Id()).To(Equal(prefix))
+		Expect(client.ListTablesCallCount()).To(Equal(2))
+		Expect(client.DescribeTableCallCount()).To(Equal(2))
+
+		Expect(data.Get(csbdynamodbns.TablesKey)).To(Equal([]any{
+			map[string]any{
+				"name":         prefix + "one",
+				"arn":          "arn:aws:dynamodb:us-west-2:123456789012:table/" + prefix + "one",
+				"item_count":   42,
+				"size_bytes":   1024,
+				"billing_mode": "PAY_PER_REQUEST",
+			},
+			map[string]any{
+				"name":         prefix + "two",
+				"arn":          "arn:aws:dynamodb:us-west-2:123456789012:table/" + prefix + "two",
+				"item_count":   0,
+				"size_bytes":   0,
+				"billing_mode": "PROVISIONED",
+			},
+		}))
+	})
+
+	It("leaves out the tables of other instances", func() {
+		config.IsStrictPrefixMatchReturns(true)
+		config.GetInstanceIDReturns("this-instance")
+		client.ListTagsOfResourceCalls(func(_ context.Context, input *dynamodb.ListTagsOfResourceInput, _ ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+			instanceID := "this-instance"
+			if strings.HasSuffix(*input.ResourceArn, "two") {
+				instanceID = "other-instance"
+			}
+			return &dynamodb.ListTagsOfResourceOutput{Tags: []types.Tag{{Key: ptr.String(csbdynamodbns.InstanceIDTagKey), Value: ptr.String(instanceID)}}}, nil
+		})
+
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d).To(BeNil())
+		Expect(data.Get(csbdynamodbns.TablesKey)).To(ConsistOf(HaveKeyWithValue("name", prefix+"one")))
+	})
+
+	It("skips tables that are deleted after being listed", func() {
+		stub := client.DescribeTableStub
+		client.DescribeTableCalls(func(ctx context.Context, input *dynamodb.DescribeTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+			if *input.TableName == prefix+"one" {
+				return nil, &types.ResourceNotFoundException{}
+			}
+			return stub(ctx, input, opts...)
+		})
+
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d).To(BeNil())
+		Expect(data.Get(csbdynamodbns.TablesKey)).To(HaveLen(1))
+	})
+
+	It("reports listing errors", func() {
+		client.ListTablesReturnsOnCall(1, nil, fmt.Errorf("connection issues"))
+
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("connection issues"))
+	})
+
+	It("reports describe errors", func() {
+		client.DescribeTableCalls(nil)
+		client.DescribeTableReturns(nil, fmt.Errorf("access denied"))
+
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("access denied"))
+	})
+
+	It("reports client errors", func() {
+		config.GetClientReturns(nil, fmt.Errorf("bad credentials"))
+
+		d := csbdynamodbns.DataSourceDynamoDBNSTablesRead(context.TODO(), data, config)
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("bad credentials"))
+	})
+})
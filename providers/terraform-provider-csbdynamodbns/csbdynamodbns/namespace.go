@@ -0,0 +1,186 @@
+package csbdynamodbns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// InstanceIDTagKey is the table tag that records which service instance a table belongs to
+	InstanceIDTagKey = "csb-instance-id"
+
+	// prefixDelimiters are the characters that can separate the prefix from the rest of a table name in strict mode
+	prefixDelimiters = "-_."
+)
+
+// namespace decides which tables belong to a service instance
+type namespace struct {
+	prefix     string
+	strict     bool
+	instanceID string
+}
+
+func namespaceOf(settings DynamoDBConfig) namespace {
+	return namespace{
+		prefix:     settings.GetPrefix(),
+		strict:     settings.IsStrictPrefixMatch(),
+		instanceID: settings.GetInstanceID(),
+	}
+}
+
+// contains reports whether a table name is in the namespace. In strict mode a delimiter must follow
+// the prefix, so that the prefix "csb-abc" does not match the tables of the prefix "csb-abcd".
+func (n namespace) contains(tableName string) bool {
+	rest, ok := strings.CutPrefix(tableName, n.prefix)
+	switch {
+	case !ok:
+		return false
+	case !n.strict:
+		return true
+	case n.prefix != "" && strings.ContainsRune(prefixDelimiters, rune(n.prefix[len(n.prefix)-1])):
+		// The prefix already ends with a delimiter
+		return true
+	default:
+		return rest != "" && strings.ContainsRune(prefixDelimiters, rune(rest[0]))
+	}
+}
+
+// excludeOtherInstances removes the tables that are tagged as belonging to a different service instance.
+// A delimiter cannot tell the prefix "csb-abc-" apart from "csb-abc-def-", so in strict mode the tags
+// are checked as well. Tables that cannot be checked are never deleted.
+func (n namespace) excludeOtherInstances(ctx context.Context, client DynamoDBClient, tableNames []string) (kept []string, d diag.Diagnostics) {
+	if !n.strict || n.instanceID == "" {
+		return tableNames, nil
+	}
+
+	for _, tableName := range tableNames {
+		instanceID, found, err := tableInstanceID(ctx, client, tableName)
+		var notFound *types.ResourceNotFoundException
+		switch {
+		case errors.As(err, &notFound):
+			// The table was deleted after it was listed
+		case err != nil:
+			d = append(d, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Error(),
+				Detail:   fmt.Sprintf("table %q was not deleted because its tags could not be read", tableName),
+			})
+		case found && instanceID != n.instanceID:
+			d = append(d, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("table %q was not deleted because it belongs to instance %q", tableName, instanceID),
+			})
+		default:
+			kept = append(kept, tableName)
+		}
+	}
+	return kept, d
+}
+
+// ownTables removes the tables that are tagged as belonging to a different service instance, like
+// excludeOtherInstances, but fails when the tags of a table cannot be read
+func (n namespace) ownTables(ctx context.Context, client DynamoDBClient, tableNames []string) ([]string, error) {
+	if !n.strict || n.instanceID == "" {
+		return tableNames, nil
+	}
+
+	var own []string
+	for _, tableName := range tableNames {
+		instanceID, found, err := tableInstanceID(ctx, client, tableName)
+		var notFound *types.ResourceNotFoundException
+		switch {
+		case errors.As(err, &notFound):
+			// The table was deleted after it was listed
+		case err != nil:
+			return nil, err
+		case !found || instanceID == n.instanceID:
+			own = append(own, tableName)
+		}
+	}
+	return own, nil
+}
+
+// excludeOtherInstanceBackups keeps the backups of the tables that passed excludeOtherInstances. The backups
+// of tables that no longer exist are kept unless the backup itself is tagged as belonging to a different
+// service instance, and backups that cannot be checked are never deleted.
+func (n namespace) excludeOtherInstanceBackups(ctx context.Context, client DynamoDBClient, backups []types.BackupSummary, listed, kept []string) (result []types.BackupSummary, d diag.Diagnostics) {
+	for _, backup := range backups {
+		tableName := aws.ToString(backup.TableName)
+		switch {
+		case slices.Contains(kept, tableName):
+			result = append(result, backup)
+		case slices.Contains(listed, tableName):
+			// The table was excluded, and so are its backups
+		case n.instanceID == "":
+			result = append(result, backup)
+		default:
+			instanceID, found, err := resourceInstanceID(ctx, client, backup.BackupArn)
+			switch {
+			case err != nil:
+				d = append(d, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  err.Error(),
+					Detail:   fmt.Sprintf("backup %q was not deleted because its tags could not be read", aws.ToString(backup.BackupArn)),
+				})
+			case found && instanceID != n.instanceID:
+				d = append(d, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("backup %q was not deleted because it belongs to instance %q", aws.ToString(backup.BackupArn), instanceID),
+				})
+			default:
+				result = append(result, backup)
+			}
+		}
+	}
+	return result, d
+}
+
+// tableInstanceID returns the value of the instance ID tag of a table, if it has one
+func tableInstanceID(ctx context.Context, client DynamoDBClient, tableName string) (string, bool, error) {
+	table, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	switch {
+	case err != nil:
+		return "", false, err
+	case table == nil || table.Table == nil:
+		return "", false, fmt.Errorf("no description returned for table %q", tableName)
+	}
+
+	return resourceInstanceID(ctx, client, table.Table.TableArn)
+}
+
+// resourceInstanceID returns the value of the instance ID tag of a table or backup, if it has one
+func resourceInstanceID(ctx context.Context, client DynamoDBClient, arn *string) (string, bool, error) {
+	var next *string
+	for {
+		output, err := client.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: arn,
+			NextToken:   next,
+		})
+		switch {
+		case err != nil:
+			return "", false, err
+		case output == nil:
+			return "", false, nil
+		}
+
+		for _, tag := range output.Tags {
+			if aws.ToString(tag.Key) == InstanceIDTagKey {
+				return aws.ToString(tag.Value), true, nil
+			}
+		}
+
+		// Stop on a repeated token in order to avoid an infinite loop
+		if output.NextToken == nil || aws.ToString(output.NextToken) == aws.ToString(next) {
+			return "", false, nil
+		}
+		next = output.NextToken
+	}
+}
@@ -3,6 +3,7 @@ package csbdynamodbns
 
 import (
 	"context"
+	"maps"
 	"net/url"
 	"regexp"
 
@@ -14,13 +15,15 @@ const (
 	awsRegionKey         = "region"
 	dynamoDBPrefixKey    = "prefix"
 	customEndpointURLKey = "custom_endpoint_url"
+	strictPrefixMatchKey = "strict_prefix_match"
+	instanceIDKey        = "instance_id"
 )
 
 var identifierRegexp = regexp.MustCompile(`^[\w_.-]{1,64}$`)
 
 func Provider() *schema.Provider {
 	return &schema.Provider{
-		Schema: map[string]*schema.Schema{
+		Schema: withAssumeRoleSchema(map[string]*schema.Schema{
 			awsRegionKey: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -33,10 +36,25 @@ func Provider() *schema.Provider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-		},
+			strictPrefixMatchKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Require a delimiter after the prefix, and never delete tables tagged with a different instance ID",
+			},
+			instanceIDKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The service instance that owns the namespace, compared with the csb-instance-id tag of the tables in strict mode",
+			},
+		}),
 		ConfigureContextFunc: providerConfigure,
 		ResourcesMap: map[string]*schema.Resource{
 			"csbdynamodbns_instance": ResourceDynamoDBNSInstance(),
+			"csbdynamodbns_quota":    ResourceDynamoDBNSQuota(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"csbdynamodbns_tables": DataSourceDynamoDBNSTables(),
 		},
 	}
 }
@@ -77,12 +95,22 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (any, diag.Dia
 	var settings = &dynamoDBNamespaceSettings{
 		region:            region,
 		prefix:            prefix,
+		strictPrefixMatch: d.Get(strictPrefixMatchKey).(bool),
+		instanceID:        d.Get(instanceIDKey).(string),
 		customEndpointURL: customEndpointURL,
+		assumeRoleARN:     d.Get(AssumeRoleARNKey).(string),
+		externalID:        d.Get(ExternalIDKey).(string),
+		roleSessionName:   d.Get(RoleSessionNameKey).(string),
 	}
 
 	return settings, diags
 }
 
+func withAssumeRoleSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	maps.Copy(s, assumeRoleSchema())
+	return s
+}
+
 func getIdentifier(d *schema.ResourceData, key string) (string, diag.Diagnostics) {
 	// We rely on Terraform to supply the correct types, and it's ok panic if this contract is broken
 	s := d.Get(key).(string)
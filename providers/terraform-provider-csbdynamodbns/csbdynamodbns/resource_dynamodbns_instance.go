@@ -2,17 +2,35 @@ package csbdynamodbns
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	AwsAccessKeyIDKey     = "access_key_id"
-	AwsSecretAccessKeyKey = "secret_access_key"
+	BackupBeforeDeleteKey   = "backup_before_delete"
+	DeletionPollIntervalKey = "deletion_poll_interval"
+	DeleteConcurrencyKey    = "delete_concurrency"
+	DryRunKey               = "dry_run"
+	ReplicaRegionsKey       = "replica_regions"
+	DeleteBackupsKey        = "delete_backups"
+
+	finalBackupNamePrefix       = "csb-final-backup"
+	defaultDeletionTimeout      = 10 * time.Minute
+	maxDeletionPollInterval     = 30 * time.Second
+	defaultDeletionPollInterval = 5 * time.Second
+	defaultDeleteConcurrency    = 1
+	maxDeleteConcurrency        = 100
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -21,27 +39,72 @@ const (
 type DynamoDBClient interface {
 	dynamodb.ListTablesAPIClient
 	DeleteTable(context.Context, *dynamodb.DeleteTableInput, ...func(options *dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
+	CreateBackup(context.Context, *dynamodb.CreateBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.CreateBackupOutput, error)
+	dynamodb.DescribeTableAPIClient
+	ListBackups(context.Context, *dynamodb.ListBackupsInput, ...func(options *dynamodb.Options)) (*dynamodb.ListBackupsOutput, error)
+	DeleteBackup(context.Context, *dynamodb.DeleteBackupInput, ...func(options *dynamodb.Options)) (*dynamodb.DeleteBackupOutput, error)
+	DescribeKinesisStreamingDestination(context.Context, *dynamodb.DescribeKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DescribeKinesisStreamingDestinationOutput, error)
+	DisableKinesisStreamingDestination(context.Context, *dynamodb.DisableKinesisStreamingDestinationInput, ...func(options *dynamodb.Options)) (*dynamodb.DisableKinesisStreamingDestinationOutput, error)
+	ListTagsOfResource(context.Context, *dynamodb.ListTagsOfResourceInput, ...func(options *dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)
 }
 
 var _ DynamoDBClient = &dynamodb.Client{}
 
+var accessKeyIDRegexp = regexp.MustCompile(`^\w{16,128}$`)
+
 func ResourceDynamoDBNSInstance() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			AwsAccessKeyIDKey: {
-				Type:     schema.TypeString,
-				Required: true,
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			BackupBeforeDeleteKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Take an on-demand backup of every table in the namespace before deleting it",
+			},
+			DeletionPollIntervalKey: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultDeletionPollInterval.String(),
+				ValidateDiagFunc: validateDuration,
+				Description:      "Initial interval between checks that deleted tables are gone, and between retries of throttled operations. The interval backs off exponentially.",
+			},
+			DeleteConcurrencyKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultDeleteConcurrency,
+				ValidateFunc: validation.IntBetween(1, maxDeleteConcurrency),
+				Description:  "Maximum number of tables deleted at the same time",
 			},
-			AwsSecretAccessKeyKey: {
-				Type:     schema.TypeString,
-				Required: true,
+			DryRunKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Report the tables that would be deleted as warnings, without deleting anything",
 			},
+			ReplicaRegionsKey: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Other regions where global table replicas of the namespace tables may exist",
+			},
+			DeleteBackupsKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the on-demand backups of the namespace tables. Backups taken because of backup_before_delete are kept, including the ones taken by an earlier attempt to delete the namespace.",
+			},
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(defaultDeletionTimeout),
 		},
 		CreateContext: setResourceID,
 		UpdateContext: setResourceID,
-		ReadContext:   setResourceID,
+		ReadContext:   ResourceDynamoDBNSInstanceRead,
 		DeleteContext: ResourceDynamoDBMaintenanceDelete,
-		Description:   "Handles DynamoDB namespace housekeeping",
+		Importer: &schema.ResourceImporter{
+			StateContext: importByAccessKeyID,
+		},
+		Description: "Handles DynamoDB namespace housekeeping",
 	}
 }
 
@@ -51,34 +114,154 @@ func setResourceID(_ context.Context, data *schema.ResourceData, _ any) diag.Dia
 	return nil
 }
 
+// ResourceDynamoDBNSInstanceRead checks that the credentials still work, and removes the resource
+// from the state when they have been revoked, as the housekeeping could never run with them.
+func ResourceDynamoDBNSInstanceRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	// An imported resource has no secret until the configuration is applied
+	if data.Get(AwsSecretAccessKeyKey).(string) == "" {
+		return setResourceID(ctx, data, config)
+	}
+
+	settings := config.(DynamoDBConfig)
+	client, err := settings.GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.ListTables(ctx, &dynamodb.ListTablesInput{Limit: aws.Int32(1)})
+	switch {
+	case isInvalidCredentials(err):
+		tflog.Warn(ctx, "Removing DynamoDB namespace housekeeping from the state because its credentials are no longer valid", map[string]any{
+			"access_key_id": data.Get(AwsAccessKeyIDKey).(string),
+			"error":         err.Error(),
+		})
+		data.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	default:
+		return setResourceID(ctx, data, config)
+	}
+}
+
+// importByAccessKeyID rebuilds the state from the access key ID. The secret access key is not
+// stored anywhere else, so it is taken from the configuration the next time it is applied.
+func importByAccessKeyID(_ context.Context, data *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	if !accessKeyIDRegexp.MatchString(data.Id()) {
+		return nil, fmt.Errorf("invalid access key ID %q, validation expression is: %s", data.Id(), accessKeyIDRegexp.String())
+	}
+
+	if err := data.Set(AwsAccessKeyIDKey, data.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+// isInvalidCredentials reports whether the access key is unknown or its secret is wrong. An expired
+// session token is not one of them: the credentials are renewed, and the resource must stay in the state.
+func isInvalidCredentials(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.ErrorCode() {
+	case "UnrecognizedClientException", "InvalidSignatureException", "InvalidClientTokenId":
+		return true
+	default:
+		return false
+	}
+}
+
 func ResourceDynamoDBMaintenanceDelete(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
 	settings := config.(DynamoDBConfig)
-	client, err := settings.GetClient(ctx, data.Get(AwsAccessKeyIDKey).(string), data.Get(AwsSecretAccessKeyKey).(string))
+	creds := credentialsFromResourceData(data)
+	client, err := settings.GetClient(ctx, creds)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	paginator := dynamodb.NewListTablesPaginator(client, &dynamodb.ListTablesInput{}, func(o *dynamodb.ListTablesPaginatorOptions) {
-		o.StopOnDuplicateToken = true
-	})
 
+	opts := deletionOptions{
+		backup:        data.Get(BackupBeforeDeleteKey).(bool),
+		backupName:    fmt.Sprintf("%s-%d", finalBackupNamePrefix, time.Now().Unix()),
+		interval:      pollInterval(data),
+		concurrency:   deleteConcurrency(data),
+		deleteBackups: data.Get(DeleteBackupsKey).(bool),
+		dryRun:        data.Get(DryRunKey).(bool),
+		deadline:      deletionDeadline(ctx, data),
+	}
+
+	// Replicas are removed first, so that the tables in the provider region are deleted last
 	d := diag.Diagnostics{}
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
+	for _, region := range replicaRegions(data) {
+		regionalClient, err := settings.GetRegionalClient(ctx, region, creds)
 		if err != nil {
-			// We have to return immediately in order to avoid an infinite loop
-			return append(d, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
-		}
-		for _, tableName := range page.TableNames {
-			if strings.HasPrefix(tableName, settings.GetPrefix()) {
-				_, err := client.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
-				if err != nil {
-					d = append(d, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
-				}
-			}
+			d = append(d, inRegion(region, diag.FromErr(err))...)
+			continue
 		}
+		d = append(d, inRegion(region, cleanUpNamespace(ctx, regionalClient, namespaceOf(settings), opts))...)
 	}
+
+	d = append(d, cleanUpNamespace(ctx, client, namespaceOf(settings), opts)...)
+
 	if len(d) > 0 {
 		return d
 	}
 	return nil
 }
+
+func replicaRegions(data *schema.ResourceData) (regions []string) {
+	for _, region := range data.Get(ReplicaRegionsKey).([]any) {
+		regions = append(regions, region.(string))
+	}
+	return regions
+}
+
+// inRegion qualifies the diagnostics of a replica region with the region name
+func inRegion(region string, d diag.Diagnostics) diag.Diagnostics {
+	for i := range d {
+		d[i].Summary = fmt.Sprintf("%s: %s", region, d[i].Summary)
+	}
+	return d
+}
+
+// deletionDeadline honours the deadline that Terraform sets from the resource delete timeout,
+// falling back to the timeout itself when the context has no deadline
+func deletionDeadline(ctx context.Context, data *schema.ResourceData) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(data.Timeout(schema.TimeoutDelete))
+}
+
+func pollInterval(data *schema.ResourceData) time.Duration {
+	// The value has already been validated by the schema, but may be missing from state written by older versions
+	if interval, err := time.ParseDuration(data.Get(DeletionPollIntervalKey).(string)); err == nil && interval > 0 {
+		return interval
+	}
+	return defaultDeletionPollInterval
+}
+
+func deleteConcurrency(data *schema.ResourceData) int {
+	if concurrency := data.Get(DeleteConcurrencyKey).(int); concurrency > 0 {
+		return concurrency
+	}
+	return defaultDeleteConcurrency
+}
+
+func validateDuration(i any, path cty.Path) diag.Diagnostics {
+	s, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: "expected a string", AttributePath: path}}
+	}
+
+	duration, err := time.ParseDuration(s)
+	switch {
+	case err != nil:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: fmt.Sprintf("invalid duration %q", s), Detail: err.Error(), AttributePath: path}}
+	case duration <= 0:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: fmt.Sprintf("duration %q must be positive", s), AttributePath: path}}
+	default:
+		return nil
+	}
+}
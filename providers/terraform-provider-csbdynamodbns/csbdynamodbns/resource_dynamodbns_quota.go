@@ -0,0 +1,242 @@
+package csbdynamodbns
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	MaxTableCountKey = "max_table_count"
+	MaxSizeBytesKey  = "max_size_bytes"
+	EnforceQuotaKey  = "enforce"
+	TableCountKey    = "table_count"
+	SizeBytesKey     = "size_bytes"
+)
+
+func ResourceDynamoDBNSQuota() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			MaxTableCountKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of tables in the namespace. Zero means no limit.",
+			},
+			MaxSizeBytesKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum total size in bytes of the tables in the namespace. Zero means no limit.",
+			},
+			EnforceQuotaKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the most recently created tables that exceed the quota. The oldest tables are always kept.",
+			},
+			BackupBeforeDeleteKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Take an on-demand backup of every table that exceeds the quota before deleting it, as the instance resource does",
+			},
+			DryRunKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Report the tables that exceed the quota as warnings, without deleting anything",
+			},
+			TableCountKey: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			SizeBytesKey: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		}),
+		CreateContext: ResourceDynamoDBNSQuotaApply,
+		UpdateContext: ResourceDynamoDBNSQuotaApply,
+		ReadContext:   ResourceDynamoDBNSQuotaRead,
+		DeleteContext: func(context.Context, *schema.ResourceData, any) diag.Diagnostics { return nil },
+		CustomizeDiff: customizeQuotaDiff,
+		Description:   "Reports, and optionally enforces, a quota on the tables in a DynamoDB namespace",
+	}
+}
+
+// ResourceDynamoDBNSQuotaRead measures the namespace and reports as warnings when it is over quota.
+// It runs during plans and refreshes, so it never deletes tables, even when the quota is enforced.
+func ResourceDynamoDBNSQuotaRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	settings := config.(DynamoDBConfig)
+	client, err := settings.GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tables, err := measureNamespace(ctx, client, namespaceOf(settings))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(settings.GetPrefix())
+	return setUsage(data, tables, quotaWarnings(data, settings.GetPrefix(), tables))
+}
+
+// ResourceDynamoDBNSQuotaApply measures the namespace like a read. When the quota is enforced,
+// tables are kept oldest first and the tables that do not fit are deleted, or only reported in a dry run.
+func ResourceDynamoDBNSQuotaApply(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	settings := config.(DynamoDBConfig)
+	client, err := settings.GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tables, err := measureNamespace(ctx, client, namespaceOf(settings))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(settings.GetPrefix())
+
+	d := quotaWarnings(data, settings.GetPrefix(), tables)
+	if len(d) > 0 && data.Get(EnforceQuotaKey).(bool) {
+		excess := tablesOverQuota(tables, data.Get(MaxTableCountKey).(int), int64(data.Get(MaxSizeBytesKey).(int)))
+		if data.Get(DryRunKey).(bool) {
+			for _, tableName := range excess {
+				d = append(d, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("dry run: table %q would be deleted because it exceeds the namespace quota", tableName),
+				})
+			}
+			return setUsage(data, tables, d)
+		}
+
+		deleted := make(map[string]bool, len(excess))
+		opts := deletionOptions{
+			backup:      data.Get(BackupBeforeDeleteKey).(bool),
+			backupName:  fmt.Sprintf("%s-%d", finalBackupNamePrefix, time.Now().Unix()),
+			interval:    defaultDeletionPollInterval,
+			concurrency: 1,
+		}
+		for i, result := range deleteTables(ctx, client, excess, opts) {
+			d = append(d, result.diags...)
+			if result.deleted {
+				deleted[excess[i]] = true
+				d = append(d, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("table %q was deleted because it exceeds the namespace quota", excess[i]),
+				})
+			}
+		}
+
+		tables = slices.DeleteFunc(tables, func(table *types.TableDescription) bool {
+			return deleted[aws.ToString(table.TableName)]
+		})
+	}
+
+	return setUsage(data, tables, d)
+}
+
+// customizeQuotaDiff plans an update when the last refresh found the namespace over an enforced quota,
+// as tables are only deleted during an apply. A dry run would never bring the namespace within quota.
+func customizeQuotaDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" || !diff.Get(EnforceQuotaKey).(bool) || diff.Get(DryRunKey).(bool) {
+		return nil
+	}
+
+	maxTableCount := diff.Get(MaxTableCountKey).(int)
+	maxSizeBytes := diff.Get(MaxSizeBytesKey).(int)
+	if (maxTableCount > 0 && diff.Get(TableCountKey).(int) > maxTableCount) || (maxSizeBytes > 0 && diff.Get(SizeBytesKey).(int) > maxSizeBytes) {
+		if err := diff.SetNewComputed(TableCountKey); err != nil {
+			return err
+		}
+		return diff.SetNewComputed(SizeBytesKey)
+	}
+	return nil
+}
+
+// measureNamespace describes the tables in the namespace. Tables that are tagged as belonging to
+// another service instance are left out, so that they do not count against this quota.
+func measureNamespace(ctx context.Context, client DynamoDBClient, ns namespace) ([]*types.TableDescription, error) {
+	tableNames, err := listTablesInNamespace(ctx, client, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNames, err = ns.ownTables(ctx, client, tableNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return describeTables(ctx, client, tableNames)
+}
+
+func quotaWarnings(data *schema.ResourceData, prefix string, tables []*types.TableDescription) (d diag.Diagnostics) {
+	maxTableCount := data.Get(MaxTableCountKey).(int)
+	maxSizeBytes := int64(data.Get(MaxSizeBytesKey).(int))
+	tableCount, sizeBytes := len(tables), totalSizeBytes(tables)
+
+	if maxTableCount > 0 && tableCount > maxTableCount {
+		d = append(d, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("namespace %q is over quota: it has %d tables and the maximum is %d", prefix, tableCount, maxTableCount),
+		})
+	}
+	if maxSizeBytes > 0 && sizeBytes > maxSizeBytes {
+		d = append(d, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("namespace %q is over quota: its tables use %d bytes and the maximum is %d", prefix, sizeBytes, maxSizeBytes),
+		})
+	}
+	return d
+}
+
+func setUsage(data *schema.ResourceData, tables []*types.TableDescription, d diag.Diagnostics) diag.Diagnostics {
+	if err := data.Set(TableCountKey, len(tables)); err != nil {
+		return append(d, diag.FromErr(err)...)
+	}
+	if err := data.Set(SizeBytesKey, int(totalSizeBytes(tables))); err != nil {
+		return append(d, diag.FromErr(err)...)
+	}
+	return d
+}
+
+// tablesOverQuota returns the names of the tables that do not fit in the quota. Tables are kept in
+// creation order while they fit, so the oldest tables are always protected.
+func tablesOverQuota(tables []*types.TableDescription, maxTableCount int, maxSizeBytes int64) []string {
+	sorted := slices.Clone(tables)
+	slices.SortStableFunc(sorted, func(a, b *types.TableDescription) int {
+		return aws.ToTime(a.CreationDateTime).Compare(aws.ToTime(b.CreationDateTime))
+	})
+
+	var (
+		excess    []string
+		count     int
+		sizeBytes int64
+	)
+	for _, table := range sorted {
+		size := aws.ToInt64(table.TableSizeBytes)
+		if (maxTableCount > 0 && count >= maxTableCount) || (maxSizeBytes > 0 && sizeBytes+size > maxSizeBytes) {
+			excess = append(excess, aws.ToString(table.TableName))
+			continue
+		}
+		count++
+		sizeBytes += size
+	}
+	return excess
+}
+
+func totalSizeBytes(tables []*types.TableDescription) (total int64) {
+	for _, table := range tables {
+		total += aws.ToInt64(table.TableSizeBytes)
+	}
+	return total
+}
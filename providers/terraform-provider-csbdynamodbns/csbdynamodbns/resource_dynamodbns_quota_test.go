@@ -0,0 +1,263 @@
+package csbdynamodbns_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pborman/uuid"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/csbdynamodbns"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-dynamodbns/csbdynamodbns/csbdynamodbnsfakes"
+)
+
+var _ = Describe("ResourceDynamoDBNSQuota", func() {
+	var (
+		client *csbdynamodbnsfakes.FakeDynamoDBClient
+		config *csbdynamodbnsfakes.FakeDynamoDBConfig
+		data   *schema.ResourceData
+		prefix string
+	)
+
+	BeforeEach(func() {
+		prefix = fmt.Sprintf("csb-%s-", uuid.New())
+
+		client = &csbdynamodbnsfakes.FakeDynamoDBClient{}
+		config = &csbdynamodbnsfakes.FakeDynamoDBConfig{}
+		config.GetClientReturns(client, nil)
+		config.GetPrefixReturns(prefix)
+
+		data = csbdynamodbns.ResourceDynamoDBNSQuota().TestResourceData()
+		Expect(data.Set(csbdynamodbns.AwsAccessKeyIDKey, "id")).NotTo(HaveOccurred())
+		Expect(data.Set(csbdynamodbns.AwsSecretAccessKeyKey, "key")).NotTo(HaveOccurred())
+
+		// Listed newest first, to show that the creation time decides which tables are protected
+		created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		tables := map[string]*types.TableDescription{
+			prefix + "newest": {CreationDateTime: ptr.Time(created.Add(2 * time.Hour)), TableSizeBytes: ptr.Int64(300)},
+			prefix + "middle": {CreationDateTime: ptr.Time(created.Add(time.Hour)), TableSizeBytes: ptr.Int64(200)},
+			prefix + "oldest": {CreationDateTime: ptr.Time(created), TableSizeBytes: ptr.Int64(100)},
+		}
+		client.ListTablesReturns(&dynamodb.ListTablesOutput{TableNames: []string{
+			prefix + "newest",
+			"not-in-the-namespace",
+			prefix + "middle",
+			prefix + "oldest",
+		}}, nil)
+		client.DescribeTableCalls(func(_ context.Context, input *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+			table := *tables[*input.TableName]
+			table.TableName = input.TableName
+			table.TableArn = ptr.String("arn:aws:dynamodb:us-west-2:123456789012:table/" + *input.TableName)
+			return &dynamodb.DescribeTableOutput{Table: &table}, nil
+		})
+	})
+
+	It("measures the namespace", func() {
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d).To(BeEmpty())
+		Expect(data.Id()).To(Equal(prefix))
+		Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(3))
+		Expect(data.Get(csbdynamodbns.SizeBytesKey)).To(Equal(600))
+	})
+
+	It("does not warn when the namespace is within quota", func() {
+		Expect(data.Set(csbdynamodbns.MaxTableCountKey, 3)).NotTo(HaveOccurred())
+		Expect(data.Set(csbdynamodbns.MaxSizeBytesKey, 600)).NotTo(HaveOccurred())
+
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d).To(BeEmpty())
+	})
+
+	It("warns when the namespace has too many tables", func() {
+		Expect(data.Set(csbdynamodbns.MaxTableCountKey, 2)).NotTo(HaveOccurred())
+
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Warning))
+		Expect(d[0].Summary).To(Equal(fmt.Sprintf("namespace %q is over quota: it has 3 tables and the maximum is 2", prefix)))
+		Expect(client.DeleteTableCallCount()).To(BeZero())
+	})
+
+	It("warns when the namespace uses too much storage", func() {
+		Expect(data.Set(csbdynamodbns.MaxSizeBytesKey, 500)).NotTo(HaveOccurred())
+
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Warning))
+		Expect(d[0].Summary).To(Equal(fmt.Sprintf("namespace %q is over quota: its tables use 600 bytes and the maximum is 500", prefix)))
+	})
+
+	It("does not count the tables of other instances", func() {
+		config.IsStrictPrefixMatchReturns(true)
+		config.GetInstanceIDReturns("this-instance")
+		client.ListTagsOfResourceCalls(func(_ context.Context, input *dynamodb.ListTagsOfResourceInput, _ ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+			instanceID := "this-instance"
+			if strings.HasSuffix(*input.ResourceArn, "newest") {
+				instanceID = "other-instance"
+			}
+			return &dynamodb.ListTagsOfResourceOutput{Tags: []types.Tag{{Key: ptr.String(csbdynamodbns.InstanceIDTagKey), Value: ptr.String(instanceID)}}}, nil
+		})
+
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d).To(BeEmpty())
+		Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(2))
+		Expect(data.Get(csbdynamodbns.SizeBytesKey)).To(Equal(300))
+	})
+
+	When("the quota is enforced", func() {
+		BeforeEach(func() {
+			Expect(data.Set(csbdynamodbns.EnforceQuotaKey, true)).NotTo(HaveOccurred())
+		})
+
+		It("never deletes tables when the namespace is read", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 1)).NotTo(HaveOccurred())
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(client.DeleteTableCallCount()).To(BeZero())
+			Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(3))
+		})
+
+		It("plans an update when the last read found the namespace over quota", func() {
+			resource := csbdynamodbns.ResourceDynamoDBNSQuota()
+			state := &terraform.InstanceState{ID: prefix, Attributes: map[string]string{
+				"id":                                prefix,
+				csbdynamodbns.AwsAccessKeyIDKey:     "id",
+				csbdynamodbns.AwsSecretAccessKeyKey: "key",
+				csbdynamodbns.MaxTableCountKey:      "2",
+				csbdynamodbns.EnforceQuotaKey:       "true",
+				csbdynamodbns.BackupBeforeDeleteKey: "false",
+				csbdynamodbns.DryRunKey:             "false",
+				csbdynamodbns.TableCountKey:         "3",
+				csbdynamodbns.SizeBytesKey:          "600",
+			}}
+			raw := map[string]any{
+				csbdynamodbns.AwsAccessKeyIDKey:     "id",
+				csbdynamodbns.AwsSecretAccessKeyKey: "key",
+				csbdynamodbns.MaxTableCountKey:      2,
+				csbdynamodbns.EnforceQuotaKey:       true,
+			}
+
+			diff, err := resource.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Attributes).To(HaveKeyWithValue(csbdynamodbns.TableCountKey, HaveField("NewComputed", BeTrue())))
+
+			state.Attributes[csbdynamodbns.TableCountKey] = "2"
+			diff, err = resource.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeNil())
+
+			// A dry run would plan the same update on every plan
+			state.Attributes[csbdynamodbns.TableCountKey] = "3"
+			state.Attributes[csbdynamodbns.DryRunKey] = "true"
+			raw[csbdynamodbns.DryRunKey] = true
+			diff, err = resource.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeNil())
+		})
+
+		It("deletes the tables created after the table count was reached", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 1)).NotTo(HaveOccurred())
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeFalse())
+			Expect(client.DeleteTableCallCount()).To(Equal(2))
+
+			_, first, _ := client.DeleteTableArgsForCall(0)
+			Expect(*first.TableName).To(Equal(prefix + "middle"))
+			_, second, _ := client.DeleteTableArgsForCall(1)
+			Expect(*second.TableName).To(Equal(prefix + "newest"))
+
+			Expect(d).To(ContainElement(HaveField("Summary", fmt.Sprintf("table %q was deleted because it exceeds the namespace quota", prefix+"newest"))))
+			Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(1))
+			Expect(data.Get(csbdynamodbns.SizeBytesKey)).To(Equal(100))
+		})
+
+		It("deletes the tables created after the storage quota was reached", func() {
+			Expect(data.Set(csbdynamodbns.MaxSizeBytesKey, 250)).NotTo(HaveOccurred())
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeFalse())
+			Expect(client.DeleteTableCallCount()).To(Equal(2))
+
+			_, first, _ := client.DeleteTableArgsForCall(0)
+			Expect(*first.TableName).To(Equal(prefix + "middle"))
+			_, second, _ := client.DeleteTableArgsForCall(1)
+			Expect(*second.TableName).To(Equal(prefix + "newest"))
+
+			Expect(data.Get(csbdynamodbns.SizeBytesKey)).To(BeNumerically("<=", 250))
+		})
+
+		It("backs up the tables before deleting them, like the instance resource", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 2)).NotTo(HaveOccurred())
+			Expect(data.Set(csbdynamodbns.BackupBeforeDeleteKey, true)).NotTo(HaveOccurred())
+			client.CreateBackupReturns(&dynamodb.CreateBackupOutput{BackupDetails: &types.BackupDetails{BackupArn: ptr.String("arn-backup")}}, nil)
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeFalse())
+			Expect(client.CreateBackupCallCount()).To(Equal(1))
+			_, input, _ := client.CreateBackupArgsForCall(0)
+			Expect(*input.TableName).To(Equal(prefix + "newest"))
+			Expect(*input.BackupName).To(HavePrefix("csb-final-backup-"))
+			Expect(client.DeleteTableCallCount()).To(Equal(1))
+		})
+
+		It("keeps a table whose backup failed", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 2)).NotTo(HaveOccurred())
+			Expect(data.Set(csbdynamodbns.BackupBeforeDeleteKey, true)).NotTo(HaveOccurred())
+			client.CreateBackupReturns(nil, fmt.Errorf("backup failed"))
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeTrue())
+			Expect(client.DeleteTableCallCount()).To(BeZero())
+			Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(3))
+		})
+
+		It("only reports the tables that exceed the quota in a dry run", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 2)).NotTo(HaveOccurred())
+			Expect(data.Set(csbdynamodbns.DryRunKey, true)).NotTo(HaveOccurred())
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeFalse())
+			Expect(d).To(ContainElement(HaveField("Summary", fmt.Sprintf("dry run: table %q would be deleted because it exceeds the namespace quota", prefix+"newest"))))
+			Expect(client.CreateBackupCallCount()).To(BeZero())
+			Expect(client.DeleteTableCallCount()).To(BeZero())
+			Expect(data.Get(csbdynamodbns.TableCountKey)).To(Equal(3))
+		})
+
+		It("reports deletion errors", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 2)).NotTo(HaveOccurred())
+			client.DeleteTableReturns(nil, fmt.Errorf("access denied"))
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d).To(ContainElement(HaveField("Summary", "access denied")))
+		})
+
+		It("does not delete anything when the namespace is within quota", func() {
+			Expect(data.Set(csbdynamodbns.MaxTableCountKey, 3)).NotTo(HaveOccurred())
+
+			d := csbdynamodbns.ResourceDynamoDBNSQuotaApply(context.TODO(), data, config)
+			Expect(d).To(BeEmpty())
+			Expect(client.DeleteTableCallCount()).To(BeZero())
+		})
+	})
+
+	It("reports listing errors", func() {
+		client.ListTablesReturns(nil, fmt.Errorf("ouch"))
+
+		d := csbdynamodbns.ResourceDynamoDBNSQuotaRead(context.TODO(), data, config)
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("ouch"))
+	})
+})
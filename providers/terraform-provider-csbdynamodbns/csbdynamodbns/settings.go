@@ -8,14 +8,22 @@ import (
 
 //counterfeiter:generate -header csbdynamodbnsfakes/header.txt . DynamoDBConfig
 type DynamoDBConfig interface {
-	GetClient(ctx context.Context, keyID, secretKey string) (DynamoDBClient, error)
+	GetClient(ctx context.Context, creds dynaclient.Credentials) (DynamoDBClient, error)
+	GetRegionalClient(ctx context.Context, region string, creds dynaclient.Credentials) (DynamoDBClient, error)
 	GetPrefix() string
+	IsStrictPrefixMatch() bool
+	GetInstanceID() string
 }
 
 type dynamoDBNamespaceSettings struct {
 	region            string
 	prefix            string
+	strictPrefixMatch bool
+	instanceID        string
 	customEndpointURL string
+	assumeRoleARN     string
+	externalID        string
+	roleSessionName   string
 }
 
 // Fail fast if the interface is not implemented
@@ -25,6 +33,32 @@ func (d *dynamoDBNamespaceSettings) GetPrefix() string {
 	return d.prefix
 }
 
-func (d *dynamoDBNamespaceSettings) GetClient(ctx context.Context, keyID, secretKey string) (DynamoDBClient, error) {
-	return dynaclient.New(ctx, d.region, keyID, secretKey, d.customEndpointURL)
+func (d *dynamoDBNamespaceSettings) IsStrictPrefixMatch() bool {
+	return d.strictPrefixMatch
+}
+
+func (d *dynamoDBNamespaceSettings) GetInstanceID() string {
+	return d.instanceID
+}
+
+func (d *dynamoDBNamespaceSettings) GetClient(ctx context.Context, creds dynaclient.Credentials) (DynamoDBClient, error) {
+	return d.GetRegionalClient(ctx, d.region, creds)
+}
+
+func (d *dynamoDBNamespaceSettings) GetRegionalClient(ctx context.Context, region string, creds dynaclient.Credentials) (DynamoDBClient, error) {
+	return dynaclient.New(ctx, region, d.withAssumeRoleDefaults(creds), d.customEndpointURL)
+}
+
+// withAssumeRoleDefaults fills in the role settings that the resource does not override
+func (d *dynamoDBNamespaceSettings) withAssumeRoleDefaults(creds dynaclient.Credentials) dynaclient.Credentials {
+	if creds.AssumeRoleARN == "" {
+		creds.AssumeRoleARN = d.assumeRoleARN
+	}
+	if creds.ExternalID == "" {
+		creds.ExternalID = d.externalID
+	}
+	if creds.RoleSessionName == "" {
+		creds.RoleSessionName = d.roleSessionName
+	}
+	return creds
 }
@@ -0,0 +1,334 @@
+package csbdynamodbns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const maxLimitExceededRetries = 5
+
+type deletionOptions struct {
+	backup        bool
+	backupName    string
+	interval      time.Duration
+	concurrency   int
+	deleteBackups bool
+	dryRun        bool
+	deadline      time.Time
+}
+
+type tableDeletion struct {
+	diags   diag.Diagnostics
+	deleted bool
+}
+
+// cleanUpNamespace deletes the tables in the namespace, and optionally their backups, using a client for a single region
+func cleanUpNamespace(ctx context.Context, client DynamoDBClient, ns namespace, opts deletionOptions) diag.Diagnostics {
+	// Tables listed before a listing error are still cleaned up, and the listing error is reported last
+	listed, listErr := listTablesInNamespace(ctx, client, ns)
+	tableNames, d := ns.excludeOtherInstances(ctx, client, listed)
+
+	switch {
+	case opts.dryRun:
+		d = append(d, dryRun(ctx, client, ns, listed, tableNames, opts)...)
+	default:
+		// Existing backups are deleted before any final backup is taken, so that the final backups are kept
+		if opts.deleteBackups {
+			d = append(d, deleteBackups(ctx, client, ns, listed, tableNames, opts.interval)...)
+		}
+
+		var deleted []string
+		for i, result := range deleteTables(ctx, client, tableNames, opts) {
+			d = append(d, result.diags...)
+			if result.deleted {
+				deleted = append(deleted, tableNames[i])
+			}
+		}
+
+		d = append(d, waitForDeletion(ctx, client, deleted, opts.deadline, opts.interval)...)
+	}
+
+	if listErr != nil {
+		d = append(d, diag.Diagnostic{Severity: diag.Error, Summary: listErr.Error()})
+	}
+	return d
+}
+
+func dryRun(ctx context.Context, client DynamoDBClient, ns namespace, listed, tableNames []string, opts deletionOptions) (d diag.Diagnostics) {
+	if opts.deleteBackups {
+		backups, guardDiags, err := listInstanceBackups(ctx, client, ns, listed, tableNames)
+		d = append(d, guardDiags...)
+		for _, backup := range backups {
+			d = append(d, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("dry run: backup %q of table %q would be deleted", aws.ToString(backup.BackupName), aws.ToString(backup.TableName)),
+				Detail:   aws.ToString(backup.BackupArn),
+			})
+		}
+		if err != nil {
+			d = append(d, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
+		}
+	}
+
+	for _, tableName := range tableNames {
+		d = append(d, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("dry run: table %q would be deleted", tableName),
+		})
+	}
+	return d
+}
+
+// listTablesInNamespace returns the names of all the tables in the namespace.
+// When listing fails part way through, the tables found so far are returned alongside the error.
+func listTablesInNamespace(ctx context.Context, client dynamodb.ListTablesAPIClient, ns namespace) ([]string, error) {
+	paginator := dynamodb.NewListTablesPaginator(client, &dynamodb.ListTablesInput{}, func(o *dynamodb.ListTablesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var tableNames []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// We have to return immediately in order to avoid an infinite loop
+			return tableNames, err
+		}
+		for _, tableName := range page.TableNames {
+			if ns.contains(tableName) {
+				tableNames = append(tableNames, tableName)
+			}
+		}
+	}
+
+	return tableNames, nil
+}
+
+// deleteTables deletes the tables using a bounded pool of workers. The results are returned in the
+// same order as the table names, so that the diagnostics do not depend on the order in which deletions complete.
+func deleteTables(ctx context.Context, client DynamoDBClient, tableNames []string, opts deletionOptions) []tableDeletion {
+	results := make([]tableDeletion, len(tableNames))
+	semaphore := make(chan struct{}, max(opts.concurrency, 1))
+
+	var wg sync.WaitGroup
+	for i, tableName := range tableNames {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[i] = deleteTable(ctx, client, tableName, opts)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func deleteTable(ctx context.Context, client DynamoDBClient, tableName string, opts deletionOptions) (result tableDeletion) {
+	if opts.backup {
+		backupDiag, ok := backupTable(ctx, client, tableName, opts.backupName, opts.interval)
+		result.diags = append(result.diags, backupDiag)
+		if !ok {
+			// Never delete a table whose backup could not be taken, and keep its streaming too
+			return result
+		}
+	}
+
+	result.diags = append(result.diags, disableKinesisStreamingDestinations(ctx, client, tableName)...)
+
+	err := retryOnLimitExceeded(ctx, opts.interval, func() error {
+		_, err := client.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+		return err
+	})
+	if err != nil {
+		result.diags = append(result.diags, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
+		return result
+	}
+
+	result.deleted = true
+	return result
+}
+
+// backupTable takes an on-demand backup of a table. The returned diagnostic is a warning
+// recording the backup ARN on success, or an error when the backup could not be created.
+func backupTable(ctx context.Context, client DynamoDBClient, tableName, backupName string, interval time.Duration) (diag.Diagnostic, bool) {
+	var output *dynamodb.CreateBackupOutput
+	err := retryOnLimitExceeded(ctx, interval, func() (err error) {
+		output, err = client.CreateBackup(ctx, &dynamodb.CreateBackupInput{
+			TableName:  aws.String(tableName),
+			BackupName: aws.String(backupName),
+		})
+		return err
+	})
+	switch {
+	case err != nil:
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   fmt.Sprintf("table %q was not deleted because the backup failed", tableName),
+		}, false
+	case output == nil || output.BackupDetails == nil:
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("no backup details returned for table %q", tableName),
+			Detail:   fmt.Sprintf("table %q was not deleted because the backup failed", tableName),
+		}, false
+	default:
+		return diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("table %q was backed up before deletion", tableName),
+			Detail:   aws.ToString(output.BackupDetails.BackupArn),
+		}, true
+	}
+}
+
+// retryOnLimitExceeded retries an operation that DynamoDB rejected because too many control plane
+// operations are running at the same time. The delay between attempts doubles every time.
+func retryOnLimitExceeded(ctx context.Context, interval time.Duration, operation func() error) error {
+	delay := interval
+	for attempt := 0; ; attempt++ {
+		err := operation()
+
+		var limitExceeded *types.LimitExceededException
+		if !errors.As(err, &limitExceeded) || attempt == maxLimitExceededRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxDeletionPollInterval)
+	}
+}
+
+// disableKinesisStreamingDestinations stops any change data capture to Kinesis that apps may have enabled.
+// Deleting the table would also stop it, so failures are only reported as warnings.
+func disableKinesisStreamingDestinations(ctx context.Context, client DynamoDBClient, tableName string) (d diag.Diagnostics) {
+	output, err := client.DescribeKinesisStreamingDestination(ctx, &dynamodb.DescribeKinesisStreamingDestinationInput{TableName: aws.String(tableName)})
+	switch {
+	case err != nil:
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("could not describe the Kinesis streaming destinations of table %q", tableName)}}
+	case output == nil:
+		return nil
+	}
+
+	for _, destination := range output.KinesisDataStreamDestinations {
+		if destination.DestinationStatus != types.DestinationStatusActive {
+			continue
+		}
+
+		_, err := client.DisableKinesisStreamingDestination(ctx, &dynamodb.DisableKinesisStreamingDestinationInput{
+			TableName: aws.String(tableName),
+			StreamArn: destination.StreamArn,
+		})
+		if err != nil {
+			d = append(d, diag.Diagnostic{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("could not disable Kinesis streaming destination %q of table %q", aws.ToString(destination.StreamArn), tableName)})
+		}
+	}
+	return d
+}
+
+// listBackupsInNamespace returns the on-demand backups of the tables in the namespace.
+// When listing fails part way through, the backups found so far are returned alongside the error.
+func listBackupsInNamespace(ctx context.Context, client DynamoDBClient, ns namespace) ([]types.BackupSummary, error) {
+	var (
+		backups []types.BackupSummary
+		start   *string
+	)
+	for {
+		page, err := client.ListBackups(ctx, &dynamodb.ListBackupsInput{
+			BackupType:              types.BackupTypeFilterUser,
+			ExclusiveStartBackupArn: start,
+		})
+		if err != nil {
+			return backups, err
+		}
+
+		for _, backup := range page.BackupSummaries {
+			if ns.contains(aws.ToString(backup.TableName)) {
+				backups = append(backups, backup)
+			}
+		}
+
+		// Stop on a repeated token in order to avoid an infinite loop
+		if page.LastEvaluatedBackupArn == nil || aws.ToString(page.LastEvaluatedBackupArn) == aws.ToString(start) {
+			return backups, nil
+		}
+		start = page.LastEvaluatedBackupArn
+	}
+}
+
+// listInstanceBackups returns the on-demand backups in the namespace that belong to the service instance.
+// listed are the tables in the namespace, and tableNames the ones that passed excludeOtherInstances.
+// Final backups are left out, as an earlier attempt to delete the namespace may have taken them.
+func listInstanceBackups(ctx context.Context, client DynamoDBClient, ns namespace, listed, tableNames []string) ([]types.BackupSummary, diag.Diagnostics, error) {
+	backups, err := listBackupsInNamespace(ctx, client, ns)
+	backups = slices.DeleteFunc(backups, func(backup types.BackupSummary) bool {
+		return strings.HasPrefix(aws.ToString(backup.BackupName), finalBackupNamePrefix+"-")
+	})
+	backups, d := ns.excludeOtherInstanceBackups(ctx, client, backups, listed, tableNames)
+	return backups, d, err
+}
+
+func deleteBackups(ctx context.Context, client DynamoDBClient, ns namespace, listed, tableNames []string, interval time.Duration) diag.Diagnostics {
+	backups, d, listErr := listInstanceBackups(ctx, client, ns, listed, tableNames)
+	for _, backup := range backups {
+		err := retryOnLimitExceeded(ctx, interval, func() error {
+			_, err := client.DeleteBackup(ctx, &dynamodb.DeleteBackupInput{BackupArn: backup.BackupArn})
+			return err
+		})
+		if err != nil {
+			d = append(d, diag.Diagnostic{Severity: diag.Error, Summary: err.Error(), Detail: aws.ToString(backup.BackupArn)})
+		}
+	}
+
+	if listErr != nil {
+		d = append(d, diag.Diagnostic{Severity: diag.Error, Summary: listErr.Error()})
+	}
+	return d
+}
+
+// waitForDeletion polls every deleted table until DynamoDB reports that it no longer exists,
+// so that the deprovision is not reported as done while tables are still in the DELETING state.
+func waitForDeletion(ctx context.Context, client DynamoDBClient, tableNames []string, deadline time.Time, interval time.Duration) (d diag.Diagnostics) {
+	waiter := dynamodb.NewTableNotExistsWaiter(client, func(o *dynamodb.TableNotExistsWaiterOptions) {
+		o.MinDelay = interval
+		o.MaxDelay = max(interval, maxDeletionPollInterval)
+	})
+
+	for _, tableName := range tableNames {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			d = append(d, deletionTimedOut(tableName, "deletion timeout reached"))
+			continue
+		}
+
+		if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)}, remaining); err != nil {
+			d = append(d, deletionTimedOut(tableName, err.Error()))
+		}
+	}
+
+	return d
+}
+
+func deletionTimedOut(tableName, detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("table %q did not finish deleting", tableName),
+		Detail:   detail,
+	}
+}
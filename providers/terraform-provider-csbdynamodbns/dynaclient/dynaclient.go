@@ -7,18 +7,30 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
-func New(ctx context.Context, region, keyID, secretKey, customEndpointURL string) (*dynamodb.Client, error) {
+// Credentials are static credentials, optionally used to assume a role
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	AssumeRoleARN   string
+	ExternalID      string
+	RoleSessionName string
+}
+
+func New(ctx context.Context, region string, creds Credentials, customEndpointURL string) (*dynamodb.Client, error) {
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithRegion(region),
 		config.WithCredentialsProvider(
 			aws.NewCredentialsCache(
 				credentials.NewStaticCredentialsProvider(
-					keyID,
-					secretKey,
-					"",
+					creds.AccessKeyID,
+					creds.SecretAccessKey,
+					creds.SessionToken,
 				),
 			),
 		),
@@ -27,6 +39,19 @@ func New(ctx context.Context, region, keyID, secretKey, customEndpointURL string
 		return nil, err
 	}
 
+	if creds.AssumeRoleARN != "" {
+		cfg.Credentials = aws.NewCredentialsCache(
+			stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), creds.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
+				if creds.ExternalID != "" {
+					o.ExternalID = aws.String(creds.ExternalID)
+				}
+				if creds.RoleSessionName != "" {
+					o.RoleSessionName = creds.RoleSessionName
+				}
+			}),
+		)
+	}
+
 	// For testing we use a custom endpoint
 	var opts []func(*dynamodb.Options)
 	if customEndpointURL != "" {
@@ -19,3 +19,8 @@ resource "csbdynamodbns_instance" "service_instance" {
   access_key_id     = "FAKE-access-key-id"
   secret_access_key = "FAKE-secret-access-key"
 }
+
+data "csbdynamodbns_tables" "namespace" {
+  access_key_id     = "FAKE-access-key-id"
+  secret_access_key = "FAKE-secret-access-key"
+}
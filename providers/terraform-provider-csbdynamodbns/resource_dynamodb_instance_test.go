@@ -43,7 +43,7 @@ var _ = Describe("Resource dynamodbns_instance", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		Eventually(func(g Gomega) {
-			client, err = dynaclient.New(context.TODO(), testRegion, "dummy", "dummy", localDynamoDBURL)
+			client, err = dynaclient.New(context.TODO(), testRegion, dynaclient.Credentials{AccessKeyID: "dummy", SecretAccessKey: "dummy"}, localDynamoDBURL)
 			g.Expect(err).NotTo(HaveOccurred())
 			_, err = client.ListTables(context.TODO(), nil)
 			g.Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,13 @@
+package csbiam_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCsbiam(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CSB IAM Suite")
+}
@@ -0,0 +1,290 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbiamfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-iam/csbiam"
+)
+
+type FakeIAMClient struct {
+	CreateAccessKeyStub        func(context.Context, *iam.CreateAccessKeyInput, ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error)
+	createAccessKeyMutex       sync.RWMutex
+	createAccessKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *iam.CreateAccessKeyInput
+		arg3 []func(*iam.Options)
+	}
+	createAccessKeyReturns struct {
+		result1 *iam.CreateAccessKeyOutput
+		result2 error
+	}
+	createAccessKeyReturnsOnCall map[int]struct {
+		result1 *iam.CreateAccessKeyOutput
+		result2 error
+	}
+	DeleteAccessKeyStub        func(context.Context, *iam.DeleteAccessKeyInput, ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error)
+	deleteAccessKeyMutex       sync.RWMutex
+	deleteAccessKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *iam.DeleteAccessKeyInput
+		arg3 []func(*iam.Options)
+	}
+	deleteAccessKeyReturns struct {
+		result1 *iam.DeleteAccessKeyOutput
+		result2 error
+	}
+	deleteAccessKeyReturnsOnCall map[int]struct {
+		result1 *iam.DeleteAccessKeyOutput
+		result2 error
+	}
+	ListAccessKeysStub        func(context.Context, *iam.ListAccessKeysInput, ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error)
+	listAccessKeysMutex       sync.RWMutex
+	listAccessKeysArgsForCall []struct {
+		arg1 context.Context
+		arg2 *iam.ListAccessKeysInput
+		arg3 []func(*iam.Options)
+	}
+	listAccessKeysReturns struct {
+		result1 *iam.ListAccessKeysOutput
+		result2 error
+	}
+	listAccessKeysReturnsOnCall map[int]struct {
+		result1 *iam.ListAccessKeysOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIAMClient) CreateAccessKey(arg1 context.Context, arg2 *iam.CreateAccessKeyInput, arg3 ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error) {
+	fake.createAccessKeyMutex.Lock()
+	ret, specificReturn := fake.createAccessKeyReturnsOnCall[len(fake.createAccessKeyArgsForCall)]
+	fake.createAccessKeyArgsForCall = append(fake.createAccessKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *iam.CreateAccessKeyInput
+		arg3 []func(*iam.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateAccessKeyStub
+	fakeReturns := fake.createAccessKeyReturns
+	fake.recordInvocation("CreateAccessKey", []interface{}{arg1, arg2, arg3})
+	fake.createAccessKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIAMClient) CreateAccessKeyCallCount() int {
+	fake.createAccessKeyMutex.RLock()
+	defer fake.createAccessKeyMutex.RUnlock()
+	return len(fake.createAccessKeyArgsForCall)
+}
+
+func (fake *FakeIAMClient) CreateAccessKeyCalls(stub func(context.Context, *iam.CreateAccessKeyInput, ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error)) {
+	fake.createAccessKeyMutex.Lock()
+	defer fake.createAccessKeyMutex.Unlock()
+	fake.CreateAccessKeyStub = stub
+}
+
+func (fake *FakeIAMClient) CreateAccessKeyArgsForCall(i int) (context.Context, *iam.CreateAccessKeyInput, []func(*iam.Options)) {
+	fake.createAccessKeyMutex.RLock()
+	defer fake.createAccessKeyMutex.RUnlock()
+	argsForCall := fake.createAccessKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIAMClient) CreateAccessKeyReturns(result1 *iam.CreateAccessKeyOutput, result2 error) {
+	fake.createAccessKeyMutex.Lock()
+	defer fake.createAccessKeyMutex.Unlock()
+	fake.CreateAccessKeyStub = nil
+	fake.createAccessKeyReturns = struct {
+		result1 *iam.CreateAccessKeyOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) CreateAccessKeyReturnsOnCall(i int, result1 *iam.CreateAccessKeyOutput, result2 error) {
+	fake.createAccessKeyMutex.Lock()
+	defer fake.createAccessKeyMutex.Unlock()
+	fake.CreateAccessKeyStub = nil
+	if fake.createAccessKeyReturnsOnCall == nil {
+		fake.createAccessKeyReturnsOnCall = make(map[int]struct {
+			result1 *iam.CreateAccessKeyOutput
+			result2 error
+		})
+	}
+	fake.createAccessKeyReturnsOnCall[i] = struct {
+		result1 *iam.CreateAccessKeyOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) DeleteAccessKey(arg1 context.Context, arg2 *iam.DeleteAccessKeyInput, arg3 ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error) {
+	fake.deleteAccessKeyMutex.Lock()
+	ret, specificReturn := fake.deleteAccessKeyReturnsOnCall[len(fake.deleteAccessKeyArgsForCall)]
+	fake.deleteAccessKeyArgsForCall = append(fake.deleteAccessKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *iam.DeleteAccessKeyInput
+		arg3 []func(*iam.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAccessKeyStub
+	fakeReturns := fake.deleteAccessKeyReturns
+	fake.recordInvocation("DeleteAccessKey", []interface{}{arg1, arg2, arg3})
+	fake.deleteAccessKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIAMClient) DeleteAccessKeyCallCount() int {
+	fake.deleteAccessKeyMutex.RLock()
+	defer fake.deleteAccessKeyMutex.RUnlock()
+	return len(fake.deleteAccessKeyArgsForCall)
+}
+
+func (fake *FakeIAMClient) DeleteAccessKeyCalls(stub func(context.Context, *iam.DeleteAccessKeyInput, ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error)) {
+	fake.deleteAccessKeyMutex.Lock()
+	defer fake.deleteAccessKeyMutex.Unlock()
+	fake.DeleteAccessKeyStub = stub
+}
+
+func (fake *FakeIAMClient) DeleteAccessKeyArgsForCall(i int) (context.Context, *iam.DeleteAccessKeyInput, []func(*iam.Options)) {
+	fake.deleteAccessKeyMutex.RLock()
+	defer fake.deleteAccessKeyMutex.RUnlock()
+	argsForCall := fake.deleteAccessKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIAMClient) DeleteAccessKeyReturns(result1 *iam.DeleteAccessKeyOutput, result2 error) {
+	fake.deleteAccessKeyMutex.Lock()
+	defer fake.deleteAccessKeyMutex.Unlock()
+	fake.DeleteAccessKeyStub = nil
+	fake.deleteAccessKeyReturns = struct {
+		result1 *iam.DeleteAccessKeyOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) DeleteAccessKeyReturnsOnCall(i int, result1 *iam.DeleteAccessKeyOutput, result2 error) {
+	fake.deleteAccessKeyMutex.Lock()
+	defer fake.deleteAccessKeyMutex.Unlock()
+	fake.DeleteAccessKeyStub = nil
+	if fake.deleteAccessKeyReturnsOnCall == nil {
+		fake.deleteAccessKeyReturnsOnCall = make(map[int]struct {
+			result1 *iam.DeleteAccessKeyOutput
+			result2 error
+		})
+	}
+	fake.deleteAccessKeyReturnsOnCall[i] = struct {
+		result1 *iam.DeleteAccessKeyOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) ListAccessKeys(arg1 context.Context, arg2 *iam.ListAccessKeysInput, arg3 ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error) {
+	fake.listAccessKeysMutex.Lock()
+	ret, specificReturn := fake.listAccessKeysReturnsOnCall[len(fake.listAccessKeysArgsForCall)]
+	fake.listAccessKeysArgsForCall = append(fake.listAccessKeysArgsForCall, struct {
+		arg1 context.Context
+		arg2 *iam.ListAccessKeysInput
+		arg3 []func(*iam.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ListAccessKeysStub
+	fakeReturns := fake.listAccessKeysReturns
+	fake.recordInvocation("ListAccessKeys", []interface{}{arg1, arg2, arg3})
+	fake.listAccessKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIAMClient) ListAccessKeysCallCount() int {
+	fake.listAccessKeysMutex.RLock()
+	defer fake.listAccessKeysMutex.RUnlock()
+	return len(fake.listAccessKeysArgsForCall)
+}
+
+func (fake *FakeIAMClient) ListAccessKeysCalls(stub func(context.Context, *iam.ListAccessKeysInput, ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error)) {
+	fake.listAccessKeysMutex.Lock()
+	defer fake.listAccessKeysMutex.Unlock()
+	fake.ListAccessKeysStub = stub
+}
+
+func (fake *FakeIAMClient) ListAccessKeysArgsForCall(i int) (context.Context, *iam.ListAccessKeysInput, []func(*iam.Options)) {
+	fake.listAccessKeysMutex.RLock()
+	defer fake.listAccessKeysMutex.RUnlock()
+	argsForCall := fake.listAccessKeysArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIAMClient) ListAccessKeysReturns(result1 *iam.ListAccessKeysOutput, result2 error) {
+	fake.listAccessKeysMutex.Lock()
+	defer fake.listAccessKeysMutex.Unlock()
+	fake.ListAccessKeysStub = nil
+	fake.listAccessKeysReturns = struct {
+		result1 *iam.ListAccessKeysOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) ListAccessKeysReturnsOnCall(i int, result1 *iam.ListAccessKeysOutput, result2 error) {
+	fake.listAccessKeysMutex.Lock()
+	defer fake.listAccessKeysMutex.Unlock()
+	fake.ListAccessKeysStub = nil
+	if fake.listAccessKeysReturnsOnCall == nil {
+		fake.listAccessKeysReturnsOnCall = make(map[int]struct {
+			result1 *iam.ListAccessKeysOutput
+			result2 error
+		})
+	}
+	fake.listAccessKeysReturnsOnCall[i] = struct {
+		result1 *iam.ListAccessKeysOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAccessKeyMutex.RLock()
+	defer fake.createAccessKeyMutex.RUnlock()
+	fake.deleteAccessKeyMutex.RLock()
+	defer fake.deleteAccessKeyMutex.RUnlock()
+	fake.listAccessKeysMutex.RLock()
+	defer fake.listAccessKeysMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIAMClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbiam.IAMClient = new(FakeIAMClient)
@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbiamfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-iam/csbiam"
+)
+
+type FakeIAMConfig struct {
+	GetClientStub        func(context.Context) (csbiam.IAMClient, error)
+	getClientMutex       sync.RWMutex
+	getClientArgsForCall []struct {
+		arg1 context.Context
+	}
+	getClientReturns struct {
+		result1 csbiam.IAMClient
+		result2 error
+	}
+	getClientReturnsOnCall map[int]struct {
+		result1 csbiam.IAMClient
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIAMConfig) GetClient(arg1 context.Context) (csbiam.IAMClient, error) {
+	fake.getClientMutex.Lock()
+	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
+	fake.getClientArgsForCall = append(fake.getClientArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetClientStub
+	fakeReturns := fake.getClientReturns
+	fake.recordInvocation("GetClient", []interface{}{arg1})
+	fake.getClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIAMConfig) GetClientCallCount() int {
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	return len(fake.getClientArgsForCall)
+}
+
+func (fake *FakeIAMConfig) GetClientCalls(stub func(context.Context) (csbiam.IAMClient, error)) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = stub
+}
+
+func (fake *FakeIAMConfig) GetClientArgsForCall(i int) context.Context {
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	argsForCall := fake.getClientArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIAMConfig) GetClientReturns(result1 csbiam.IAMClient, result2 error) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	fake.getClientReturns = struct {
+		result1 csbiam.IAMClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMConfig) GetClientReturnsOnCall(i int, result1 csbiam.IAMClient, result2 error) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	if fake.getClientReturnsOnCall == nil {
+		fake.getClientReturnsOnCall = make(map[int]struct {
+			result1 csbiam.IAMClient
+			result2 error
+		})
+	}
+	fake.getClientReturnsOnCall[i] = struct {
+		result1 csbiam.IAMClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIAMConfig) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIAMConfig) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbiam.IAMConfig = new(FakeIAMConfig)
@@ -0,0 +1,168 @@
+package csbiam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accessKeys are the current and previous access keys of a user, as recorded in the state
+type accessKeys struct {
+	accessKeyID             string
+	secretAccessKey         string
+	createdAt               string
+	previousAccessKeyID     string
+	previousSecretAccessKey string
+	previousExpiresAt       string
+}
+
+func accessKeysFromResourceData(data *schema.ResourceData) accessKeys {
+	return accessKeys{
+		accessKeyID:             data.Get(AccessKeyIDKey).(string),
+		secretAccessKey:         data.Get(SecretAccessKeyKey).(string),
+		createdAt:               data.Get(CreatedAtKey).(string),
+		previousAccessKeyID:     data.Get(PreviousAccessKeyIDKey).(string),
+		previousSecretAccessKey: data.Get(PreviousSecretAccessKeyKey).(string),
+		previousExpiresAt:       data.Get(PreviousExpiresAtKey).(string),
+	}
+}
+
+func priorAccessKeys(data *schema.ResourceData) accessKeys {
+	prior := func(key string) string {
+		old, _ := data.GetChange(key)
+		return old.(string)
+	}
+
+	return accessKeys{
+		accessKeyID:             prior(AccessKeyIDKey),
+		secretAccessKey:         prior(SecretAccessKeyKey),
+		createdAt:               prior(CreatedAtKey),
+		previousAccessKeyID:     prior(PreviousAccessKeyIDKey),
+		previousSecretAccessKey: prior(PreviousSecretAccessKeyKey),
+		previousExpiresAt:       prior(PreviousExpiresAtKey),
+	}
+}
+
+func (k accessKeys) save(data *schema.ResourceData) error {
+	for key, value := range map[string]string{
+		AccessKeyIDKey:             k.accessKeyID,
+		SecretAccessKeyKey:         k.secretAccessKey,
+		CreatedAtKey:               k.createdAt,
+		PreviousAccessKeyIDKey:     k.previousAccessKeyID,
+		PreviousSecretAccessKeyKey: k.previousSecretAccessKey,
+		PreviousExpiresAtKey:       k.previousExpiresAt,
+	} {
+		if err := data.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k accessKeys) withoutPrevious() accessKeys {
+	k.previousAccessKeyID = ""
+	k.previousSecretAccessKey = ""
+	k.previousExpiresAt = ""
+	return k
+}
+
+// rotationDue reports whether the current key is as old as the rotation period. A creation time
+// that cannot be parsed cannot be trusted, so the key is rotated.
+func rotationDue(createdAt string, period time.Duration, now time.Time) bool {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	return err != nil || !now.Before(created.Add(period))
+}
+
+// previousExpired reports whether there is a previous key whose grace period is over
+func previousExpired(previousAccessKeyID, expiresAt string, now time.Time) bool {
+	if previousAccessKeyID == "" {
+		return false
+	}
+
+	expires, err := time.Parse(time.RFC3339, expiresAt)
+	return err != nil || !now.Before(expires)
+}
+
+// rotateAccessKeys replaces the current key with a new one, and keeps the current key as the previous key
+// until the end of the grace period. When it fails, it returns the keys that the user has at that point.
+func rotateAccessKeys(ctx context.Context, client IAMClient, userName string, keys accessKeys, now time.Time, gracePeriod time.Duration) (accessKeys, error) {
+	// An IAM user can only have two access keys
+	if keys.previousAccessKeyID != "" {
+		var err error
+		if keys, err = deletePreviousAccessKey(ctx, client, userName, keys); err != nil {
+			return keys, err
+		}
+	}
+
+	key, err := createAccessKey(ctx, client, userName)
+	if err != nil {
+		return keys, err
+	}
+
+	key.previousAccessKeyID = keys.accessKeyID
+	key.previousSecretAccessKey = keys.secretAccessKey
+	key.previousExpiresAt = now.Add(gracePeriod).UTC().Format(time.RFC3339)
+	return key, nil
+}
+
+func deletePreviousAccessKey(ctx context.Context, client IAMClient, userName string, keys accessKeys) (accessKeys, error) {
+	if err := deleteAccessKey(ctx, client, userName, keys.previousAccessKeyID); err != nil {
+		return keys, err
+	}
+	return keys.withoutPrevious(), nil
+}
+
+// createAccessKey returns the new key as the current key, without a previous key
+func createAccessKey(ctx context.Context, client IAMClient, userName string) (accessKeys, error) {
+	output, err := client.CreateAccessKey(ctx, &iam.CreateAccessKeyInput{UserName: aws.String(userName)})
+	switch {
+	case err != nil:
+		return accessKeys{}, err
+	case output == nil || output.AccessKey == nil:
+		return accessKeys{}, fmt.Errorf("no access key returned for user %q", userName)
+	}
+
+	createdAt := time.Now()
+	if output.AccessKey.CreateDate != nil {
+		createdAt = *output.AccessKey.CreateDate
+	}
+	return accessKeys{
+		accessKeyID:     aws.ToString(output.AccessKey.AccessKeyId),
+		secretAccessKey: aws.ToString(output.AccessKey.SecretAccessKey),
+		createdAt:       createdAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// deleteAccessKey deletes a key, and succeeds when the key no longer exists
+func deleteAccessKey(ctx context.Context, client IAMClient, userName, accessKeyID string) error {
+	_, err := client.DeleteAccessKey(ctx, &iam.DeleteAccessKeyInput{
+		UserName:    aws.String(userName),
+		AccessKeyId: aws.String(accessKeyID),
+	})
+	var notFound *types.NoSuchEntityException
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+func listAccessKeyIDs(ctx context.Context, client IAMClient, userName string) (keyIDs []string, err error) {
+	paginator := iam.NewListAccessKeysPaginator(client, &iam.ListAccessKeysInput{UserName: aws.String(userName)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range page.AccessKeyMetadata {
+			keyIDs = append(keyIDs, aws.ToString(key.AccessKeyId))
+		}
+	}
+	return keyIDs, nil
+}
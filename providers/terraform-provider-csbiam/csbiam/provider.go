@@ -0,0 +1,85 @@
+// Package csbiam is a Terraform provider specialised for the binding users of the AWS brokerpak
+package csbiam
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	awsRegionKey          = "region"
+	customEndpointURLKey  = "custom_endpoint_url"
+	awsAccessKeyIDKey     = "access_key_id"
+	awsSecretAccessKeyKey = "secret_access_key"
+	awsSessionTokenKey    = "session_token"
+)
+
+var regionRegexp = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			awsRegionKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Region that IAM requests are signed for, which selects the AWS partition",
+			},
+			awsAccessKeyIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			awsSecretAccessKeyKey: {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			awsSessionTokenKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Session token for temporary credentials",
+			},
+			customEndpointURLKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Endpoint of the IAM API, for local stand-ins such as LocalStack",
+			},
+		},
+		ConfigureContextFunc: ProviderConfigure,
+		ResourcesMap: map[string]*schema.Resource{
+			"csbiam_access_keys": ResourceAccessKeys(),
+		},
+	}
+}
+
+// ProviderConfigure reads the credentials of the broker, which manages the keys of the binding users.
+// They are provider settings, rather than resource arguments as in the other providers, because the
+// resource exports the keys that it manages under the same names.
+func ProviderConfigure(_ context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+	// We rely on Terraform to supply the correct types, and it's ok panic if this contract is broken
+	region := d.Get(awsRegionKey).(string)
+	if !regionRegexp.MatchString(region) {
+		return nil, diag.Errorf("invalid value %q for %q, validation expression is: %s", region, awsRegionKey, regionRegexp.String())
+	}
+
+	var customEndpointURL string
+	if customURL, ok := d.GetOk(customEndpointURLKey); ok {
+		uri, err := url.ParseRequestURI(customURL.(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		customEndpointURL = uri.String()
+	}
+
+	return &iamSettings{
+		region:            region,
+		customEndpointURL: customEndpointURL,
+		accessKeyID:       d.Get(awsAccessKeyIDKey).(string),
+		secretAccessKey:   d.Get(awsSecretAccessKeyKey).(string),
+		sessionToken:      d.Get(awsSessionTokenKey).(string),
+	}, nil
+}
@@ -0,0 +1,263 @@
+package csbiam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	UserNameKey                = "user_name"
+	RotationPeriodKey          = "rotation_period"
+	GracePeriodKey             = "grace_period"
+	AccessKeyIDKey             = "access_key_id"
+	SecretAccessKeyKey         = "secret_access_key"
+	CreatedAtKey               = "created_at"
+	PreviousAccessKeyIDKey     = "previous_access_key_id"
+	PreviousSecretAccessKeyKey = "previous_secret_access_key"
+	PreviousExpiresAtKey       = "previous_expires_at"
+
+	defaultRotationPeriod = 90 * 24 * time.Hour
+	defaultGracePeriod    = 7 * 24 * time.Hour
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbiamfakes/header.txt . IAMClient
+type IAMClient interface {
+	CreateAccessKey(context.Context, *iam.CreateAccessKeyInput, ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error)
+	DeleteAccessKey(context.Context, *iam.DeleteAccessKeyInput, ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error)
+	iam.ListAccessKeysAPIClient
+}
+
+var _ IAMClient = &iam.Client{}
+
+func ResourceAccessKeys() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			UserNameKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the IAM user that the access keys belong to",
+			},
+			RotationPeriodKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRotationPeriod.String(),
+				ValidateFunc: validatePeriod,
+				Description:  "Age of the access key after which the next apply rotates it, as a duration such as 720h",
+			},
+			GracePeriodKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultGracePeriod.String(),
+				ValidateFunc: validatePeriod,
+				Description:  "How long the previous access key stays active after a rotation, as a duration such as 24h",
+			},
+			AccessKeyIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the current access key",
+			},
+			SecretAccessKeyKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret of the current access key",
+			},
+			CreatedAtKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the current access key was created, in RFC 3339 format",
+			},
+			PreviousAccessKeyIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the access key that the last rotation replaced, empty once its grace period is over",
+			},
+			PreviousSecretAccessKeyKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret of the access key that the last rotation replaced",
+			},
+			PreviousExpiresAtKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the grace period of the previous access key ends, in RFC 3339 format",
+			},
+		},
+		CustomizeDiff: customizeAccessKeysDiff,
+		CreateContext: ResourceAccessKeysCreate,
+		ReadContext:   ResourceAccessKeysRead,
+		UpdateContext: ResourceAccessKeysUpdate,
+		DeleteContext: ResourceAccessKeysDelete,
+		Description:   "Access keys of an IAM user, which are rotated on a schedule",
+	}
+}
+
+// customizeAccessKeysDiff plans the rotation of keys that are older than the rotation period, and the
+// deletion of previous keys whose grace period is over, so that any apply, such as the one run by a
+// service instance update or upgrade, performs them
+func customizeAccessKeysDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	// Periods that are only known at apply time are checked by the next plan
+	if !diff.NewValueKnown(RotationPeriodKey) || !diff.NewValueKnown(GracePeriodKey) {
+		return nil
+	}
+
+	rotationPeriod, gracePeriod := period(diff, RotationPeriodKey), period(diff, GracePeriodKey)
+	if gracePeriod >= rotationPeriod {
+		return fmt.Errorf("%q must be shorter than %q, so that only two access keys are active at a time", GracePeriodKey, RotationPeriodKey)
+	}
+	if diff.Id() == "" {
+		return nil
+	}
+
+	var changed []string
+	now := time.Now()
+	switch {
+	case rotationDue(diff.Get(CreatedAtKey).(string), rotationPeriod, now):
+		changed = []string{AccessKeyIDKey, SecretAccessKeyKey, CreatedAtKey, PreviousAccessKeyIDKey, PreviousSecretAccessKeyKey, PreviousExpiresAtKey}
+	case previousExpired(diff.Get(PreviousAccessKeyIDKey).(string), diff.Get(PreviousExpiresAtKey).(string), now):
+		changed = []string{PreviousAccessKeyIDKey, PreviousSecretAccessKeyKey, PreviousExpiresAtKey}
+	}
+
+	for _, key := range changed {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ResourceAccessKeysCreate(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(IAMConfig).GetClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userName := data.Get(UserNameKey).(string)
+	key, err := createAccessKey(ctx, client, userName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(userName)
+	if err := key.save(data); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// ResourceAccessKeysRead removes the resource from the state when the current key no longer exists, so that
+// Terraform creates a new one, and forgets a previous key that was deleted outside Terraform
+func ResourceAccessKeysRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(IAMConfig).GetClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keyIDs, err := listAccessKeyIDs(ctx, client, data.Id())
+	var notFound *types.NoSuchEntityException
+	switch {
+	case errors.As(err, &notFound):
+		tflog.Warn(ctx, "Removing access keys from the state because the user no longer exists", map[string]any{
+			"user_name": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	keys := accessKeysFromResourceData(data)
+	if !slices.Contains(keyIDs, keys.accessKeyID) {
+		tflog.Warn(ctx, "Removing access keys from the state because the current access key no longer exists", map[string]any{
+			"user_name":     data.Id(),
+			"access_key_id": keys.accessKeyID,
+		})
+		data.SetId("")
+		return nil
+	}
+
+	if keys.previousAccessKeyID != "" && !slices.Contains(keyIDs, keys.previousAccessKeyID) {
+		if err := keys.withoutPrevious().save(data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceAccessKeysUpdate(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(IAMConfig).GetClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The plan marks the keys that change as unknown, which reads as a change to an empty value, so the keys come
+	// from the prior state. Only what the plan decided is applied: checking the clock again could rotate keys
+	// that the plan showed as unchanged.
+	keys := priorAccessKeys(data)
+	switch {
+	case data.HasChange(AccessKeyIDKey):
+		keys, err = rotateAccessKeys(ctx, client, data.Id(), keys, time.Now(), period(data, GracePeriodKey))
+	case data.HasChange(PreviousAccessKeyIDKey):
+		keys, err = deletePreviousAccessKey(ctx, client, data.Id(), keys)
+	}
+
+	// Whatever happened before a failure is saved, so that the state matches the keys of the user
+	if saveErr := keys.save(data); saveErr != nil {
+		err = errors.Join(err, saveErr)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func ResourceAccessKeysDelete(ctx context.Context, data *schema.ResourceData, config any) (d diag.Diagnostics) {
+	client, err := config.(IAMConfig).GetClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := accessKeysFromResourceData(data)
+	for _, keyID := range []string{keys.previousAccessKeyID, keys.accessKeyID} {
+		if keyID == "" {
+			continue
+		}
+		if err := deleteAccessKey(ctx, client, data.Id(), keyID); err != nil {
+			d = append(d, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  err.Error(),
+				Detail:   fmt.Sprintf("access key %q of user %q was not deleted", keyID, data.Id()),
+			})
+		}
+	}
+	return d
+}
+
+// period returns the rotation_period or grace_period of a plan or of the state. Both have a default,
+// and only accept positive durations.
+func period(data interface{ Get(string) any }, key string) time.Duration {
+	p, _ := time.ParseDuration(data.Get(key).(string))
+	return p
+}
+
+func validatePeriod(i any, key string) (warnings []string, errs []error) {
+	if p, err := time.ParseDuration(i.(string)); err != nil || p <= 0 {
+		errs = append(errs, fmt.Errorf("expected %q to be a positive duration in hours, such as 720h, got %q", key, i))
+	}
+	return warnings, errs
+}
@@ -0,0 +1,293 @@
+package csbiam_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-iam/csbiam"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-iam/csbiam/csbiamfakes"
+)
+
+const userName = "csb-binding-user"
+
+var _ = Describe("ResourceAccessKeys", func() {
+	var (
+		client   *csbiamfakes.FakeIAMClient
+		config   *csbiamfakes.FakeIAMConfig
+		resource *schema.Resource
+		recent   string
+	)
+
+	// existing returns the state of keys created at the time, with a previous key when previousExpiresAt is set
+	existing := func(createdAt, previousExpiresAt string) *terraform.InstanceState {
+		attributes := map[string]string{
+			"id":                        userName,
+			csbiam.UserNameKey:          userName,
+			csbiam.RotationPeriodKey:    "720h",
+			csbiam.GracePeriodKey:       "24h",
+			csbiam.AccessKeyIDKey:       "AKIACURRENT",
+			csbiam.SecretAccessKeyKey:   "current-secret",
+			csbiam.CreatedAtKey:         createdAt,
+			csbiam.PreviousExpiresAtKey: previousExpiresAt,
+		}
+		if previousExpiresAt != "" {
+			attributes[csbiam.PreviousAccessKeyIDKey] = "AKIAPREVIOUS"
+			attributes[csbiam.PreviousSecretAccessKeyKey] = "previous-secret"
+		}
+		return &terraform.InstanceState{ID: userName, Attributes: attributes}
+	}
+
+	plan := func(state *terraform.InstanceState, raw map[string]any) *terraform.InstanceDiff {
+		diff, err := resource.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw), config)
+		Expect(err).NotTo(HaveOccurred())
+		return diff
+	}
+
+	// planned returns the data that an apply of the plan for the state passes to the resource
+	planned := func(state *terraform.InstanceState) *schema.ResourceData {
+		data, err := schema.InternalMap(resource.Schema).Data(state, plan(state, map[string]any{
+			csbiam.UserNameKey:       userName,
+			csbiam.RotationPeriodKey: "720h",
+			csbiam.GracePeriodKey:    "24h",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		return data
+	}
+
+	BeforeEach(func() {
+		client = &csbiamfakes.FakeIAMClient{}
+		client.CreateAccessKeyReturns(&iam.CreateAccessKeyOutput{AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("AKIANEW"),
+			SecretAccessKey: aws.String("new-secret"),
+			CreateDate:      aws.Time(time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)),
+		}}, nil)
+		client.DeleteAccessKeyReturns(&iam.DeleteAccessKeyOutput{}, nil)
+		client.ListAccessKeysReturns(&iam.ListAccessKeysOutput{AccessKeyMetadata: []types.AccessKeyMetadata{
+			{AccessKeyId: aws.String("AKIACURRENT")},
+			{AccessKeyId: aws.String("AKIAPREVIOUS")},
+		}}, nil)
+
+		config = &csbiamfakes.FakeIAMConfig{}
+		config.GetClientReturns(client, nil)
+
+		resource = csbiam.ResourceAccessKeys()
+		recent = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	})
+
+	It("creates an access key for the user", func() {
+		data := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, map[string]any{csbiam.UserNameKey: userName})
+
+		Expect(csbiam.ResourceAccessKeysCreate(context.TODO(), data, config)).To(BeNil())
+		Expect(data.Id()).To(Equal(userName))
+		Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIANEW"))
+		Expect(data.Get(csbiam.SecretAccessKeyKey)).To(Equal("new-secret"))
+		Expect(data.Get(csbiam.CreatedAtKey)).To(Equal("2026-10-18T06:00:00Z"))
+		Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(BeEmpty())
+
+		_, input, _ := client.CreateAccessKeyArgsForCall(0)
+		Expect(aws.ToString(input.UserName)).To(Equal(userName))
+	})
+
+	Describe("plan", func() {
+		It("does not change keys that are younger than the rotation period", func() {
+			Expect(plan(existing(recent, ""), map[string]any{csbiam.UserNameKey: userName, csbiam.RotationPeriodKey: "720h", csbiam.GracePeriodKey: "24h"})).To(BeNil())
+		})
+
+		It("plans the rotation of keys that are as old as the rotation period", func() {
+			diff := plan(existing("2020-01-01T00:00:00Z", ""), map[string]any{csbiam.UserNameKey: userName})
+			Expect(diff.Attributes).To(HaveKey(csbiam.AccessKeyIDKey))
+			Expect(diff.Attributes[csbiam.AccessKeyIDKey].NewComputed).To(BeTrue())
+			Expect(diff.Attributes[csbiam.PreviousAccessKeyIDKey].NewComputed).To(BeTrue())
+			Expect(diff.RequiresNew()).To(BeFalse())
+		})
+
+		It("plans the deletion of a previous key whose grace period is over", func() {
+			diff := plan(existing(recent, "2020-01-01T00:00:00Z"), map[string]any{csbiam.UserNameKey: userName, csbiam.RotationPeriodKey: "720h", csbiam.GracePeriodKey: "24h"})
+			Expect(diff.Attributes).NotTo(HaveKey(csbiam.AccessKeyIDKey))
+			Expect(diff.Attributes[csbiam.PreviousAccessKeyIDKey].NewComputed).To(BeTrue())
+		})
+
+		It("rejects a grace period that is not shorter than the rotation period", func() {
+			_, err := resource.Diff(context.TODO(), existing(recent, ""), terraform.NewResourceConfigRaw(map[string]any{
+				csbiam.UserNameKey:       userName,
+				csbiam.RotationPeriodKey: "24h",
+				csbiam.GracePeriodKey:    "48h",
+			}), config)
+			Expect(err).To(MatchError(ContainSubstring(`"grace_period" must be shorter than "rotation_period"`)))
+		})
+	})
+
+	Describe("update", func() {
+		It("rotates the keys and keeps the current key as the previous key", func() {
+			data := planned(existing("2020-01-01T00:00:00Z", ""))
+
+			before := time.Now()
+			Expect(csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.DeleteAccessKeyCallCount()).To(BeZero())
+			Expect(client.CreateAccessKeyCallCount()).To(Equal(1))
+
+			Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIANEW"))
+			Expect(data.Get(csbiam.SecretAccessKeyKey)).To(Equal("new-secret"))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(Equal("AKIACURRENT"))
+			Expect(data.Get(csbiam.PreviousSecretAccessKeyKey)).To(Equal("current-secret"))
+			expires, err := time.Parse(time.RFC3339, data.Get(csbiam.PreviousExpiresAtKey).(string))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expires).To(BeTemporally("~", before.Add(24*time.Hour), time.Minute))
+		})
+
+		It("deletes the previous key before it rotates the keys", func() {
+			data := planned(existing("2020-01-01T00:00:00Z", "2020-01-02T00:00:00Z"))
+
+			Expect(csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.DeleteAccessKeyCallCount()).To(Equal(1))
+			_, input, _ := client.DeleteAccessKeyArgsForCall(0)
+			Expect(aws.ToString(input.UserName)).To(Equal(userName))
+			Expect(aws.ToString(input.AccessKeyId)).To(Equal("AKIAPREVIOUS"))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(Equal("AKIACURRENT"))
+		})
+
+		It("deletes the previous key when its grace period is over", func() {
+			data := planned(existing(recent, "2020-01-01T00:00:00Z"))
+
+			Expect(csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.CreateAccessKeyCallCount()).To(BeZero())
+			Expect(client.DeleteAccessKeyCallCount()).To(Equal(1))
+			Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIACURRENT"))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(BeEmpty())
+			Expect(data.Get(csbiam.PreviousSecretAccessKeyKey)).To(BeEmpty())
+			Expect(data.Get(csbiam.PreviousExpiresAtKey)).To(BeEmpty())
+		})
+
+		It("keeps the keys during the grace period", func() {
+			data := planned(existing(recent, time.Now().Add(time.Hour).UTC().Format(time.RFC3339)))
+
+			Expect(csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.CreateAccessKeyCallCount()).To(BeZero())
+			Expect(client.DeleteAccessKeyCallCount()).To(BeZero())
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(Equal("AKIAPREVIOUS"))
+		})
+
+		It("does not rotate keys that became due between the plan and the apply", func() {
+			state := existing(recent, "")
+			diff := plan(state, map[string]any{
+				csbiam.UserNameKey:       userName,
+				csbiam.RotationPeriodKey: "720h",
+				csbiam.GracePeriodKey:    "48h",
+			})
+
+			// By the time of the apply, the key is as old as the rotation period
+			state.Attributes[csbiam.CreatedAtKey] = time.Now().Add(-720 * time.Hour).UTC().Format(time.RFC3339)
+			data, err := schema.InternalMap(resource.Schema).Data(state, diff)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.CreateAccessKeyCallCount()).To(BeZero())
+			Expect(client.DeleteAccessKeyCallCount()).To(BeZero())
+			Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIACURRENT"))
+		})
+
+		It("keeps the current key in the state when the new key cannot be created", func() {
+			data := planned(existing("2020-01-01T00:00:00Z", "2020-01-02T00:00:00Z"))
+			client.CreateAccessKeyReturns(nil, &types.LimitExceededException{Message: aws.String("too many keys")})
+
+			d := csbiam.ResourceAccessKeysUpdate(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Summary).To(ContainSubstring("too many keys"))
+			Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIACURRENT"))
+			Expect(data.Get(csbiam.SecretAccessKeyKey)).To(Equal("current-secret"))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(BeEmpty())
+		})
+	})
+
+	Describe("read", func() {
+		var data *schema.ResourceData
+
+		BeforeEach(func() {
+			var err error
+			data, err = schema.InternalMap(resource.Schema).Data(existing(recent, "2100-01-01T00:00:00Z"), nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps keys that exist", func() {
+			Expect(csbiam.ResourceAccessKeysRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(userName))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(Equal("AKIAPREVIOUS"))
+		})
+
+		It("forgets a previous key that no longer exists", func() {
+			client.ListAccessKeysReturns(&iam.ListAccessKeysOutput{AccessKeyMetadata: []types.AccessKeyMetadata{
+				{AccessKeyId: aws.String("AKIACURRENT")},
+			}}, nil)
+
+			Expect(csbiam.ResourceAccessKeysRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(userName))
+			Expect(data.Get(csbiam.AccessKeyIDKey)).To(Equal("AKIACURRENT"))
+			Expect(data.Get(csbiam.PreviousAccessKeyIDKey)).To(BeEmpty())
+		})
+
+		It("removes the resource from the state when the current key no longer exists", func() {
+			client.ListAccessKeysReturns(&iam.ListAccessKeysOutput{}, nil)
+
+			Expect(csbiam.ResourceAccessKeysRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(BeEmpty())
+		})
+
+		It("removes the resource from the state when the user no longer exists", func() {
+			client.ListAccessKeysReturns(nil, &types.NoSuchEntityException{Message: aws.String("not found")})
+
+			Expect(csbiam.ResourceAccessKeysRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(BeEmpty())
+		})
+
+		It("lists every page of keys", func() {
+			client.ListAccessKeysReturnsOnCall(0, &iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []types.AccessKeyMetadata{{AccessKeyId: aws.String("AKIAPREVIOUS")}},
+				IsTruncated:       true,
+				Marker:            aws.String("next"),
+			}, nil)
+
+			Expect(csbiam.ResourceAccessKeysRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(userName))
+			Expect(client.ListAccessKeysCallCount()).To(Equal(2))
+			_, input, _ := client.ListAccessKeysArgsForCall(1)
+			Expect(aws.ToString(input.Marker)).To(Equal("next"))
+		})
+	})
+
+	Describe("delete", func() {
+		It("deletes the previous and current keys", func() {
+			data, err := schema.InternalMap(resource.Schema).Data(existing(recent, "2100-01-01T00:00:00Z"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			client.DeleteAccessKeyReturnsOnCall(0, nil, &types.NoSuchEntityException{Message: aws.String("not found")})
+
+			Expect(csbiam.ResourceAccessKeysDelete(context.TODO(), data, config)).To(BeNil())
+			Expect(client.DeleteAccessKeyCallCount()).To(Equal(2))
+			_, input, _ := client.DeleteAccessKeyArgsForCall(0)
+			Expect(aws.ToString(input.AccessKeyId)).To(Equal("AKIAPREVIOUS"))
+			_, input, _ = client.DeleteAccessKeyArgsForCall(1)
+			Expect(aws.ToString(input.AccessKeyId)).To(Equal("AKIACURRENT"))
+		})
+
+		It("reports the keys that were not deleted", func() {
+			data, err := schema.InternalMap(resource.Schema).Data(existing(recent, ""), nil)
+			Expect(err).NotTo(HaveOccurred())
+			client.DeleteAccessKeyReturns(nil, errors.New("access denied"))
+
+			d := csbiam.ResourceAccessKeysDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Error))
+			Expect(d[0].Summary).To(Equal("access denied"))
+			Expect(d[0].Detail).To(Equal(`access key "AKIACURRENT" of user "csb-binding-user" was not deleted`))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package csbiam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+//counterfeiter:generate -header csbiamfakes/header.txt . IAMConfig
+type IAMConfig interface {
+	GetClient(ctx context.Context) (IAMClient, error)
+}
+
+type iamSettings struct {
+	region            string
+	customEndpointURL string
+	accessKeyID       string
+	secretAccessKey   string
+	sessionToken      string
+}
+
+// Fail fast if the interface is not implemented
+var _ IAMConfig = &iamSettings{}
+
+func (i *iamSettings) GetClient(ctx context.Context) (IAMClient, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(i.region),
+		config.WithCredentialsProvider(
+			aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(
+					i.accessKeyID,
+					i.secretAccessKey,
+					i.sessionToken,
+				),
+			),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return iam.NewFromConfig(cfg, func(o *iam.Options) {
+		if i.customEndpointURL != "" {
+			o.BaseEndpoint = aws.String(i.customEndpointURL)
+		}
+	}), nil
+}
@@ -0,0 +1,22 @@
+# Run "make init" to perform "terraform init"
+
+terraform {
+  required_providers {
+    csbiam = {
+      source  = "cloudfoundry.org/cloud-service-broker/csbiam"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "csbiam" {
+  region            = "us-west-2"
+  access_key_id     = "FAKE-access-key-id"
+  secret_access_key = "FAKE-secret-access-key"
+}
+
+resource "csbiam_access_keys" "keys" {
+  user_name       = "csb-46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  rotation_period = "720h"
+  grace_period    = "24h"
+}
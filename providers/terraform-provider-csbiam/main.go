@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-iam/csbiam"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: csbiam.Provider,
+	})
+}
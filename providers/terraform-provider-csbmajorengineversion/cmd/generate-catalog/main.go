@@ -0,0 +1,45 @@
+// Command generate-catalog refreshes the engine version catalog that the provider uses in offline mode.
+// It uses the default AWS credential chain, and needs the same permissions as the provider.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+func main() {
+	var output, region, engines string
+
+	flag.StringVar(&output, "output", "csbmajorengineversion/catalog/engine_versions.json", "path of the catalog to write")
+	flag.StringVar(&region, "region", "us-west-2", "AWS region to look up the engine versions in")
+	flag.StringVar(&engines, "engines", "aurora-mysql,aurora-postgresql,docdb,mysql,postgres,redis", "comma separated engines to include in the catalog")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		log.Fatalf("error loading the AWS configuration: %s", err)
+	}
+
+	catalog, err := csbmajorengineversion.BuildCatalog(ctx, cfg, strings.Split(engines, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(output, append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
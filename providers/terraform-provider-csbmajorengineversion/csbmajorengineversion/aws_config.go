@@ -0,0 +1,63 @@
+package csbmajorengineversion
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Credentials select how the provider authenticates to AWS
+type Credentials struct {
+	// UseDefaultChain ignores the static credentials in favour of the default AWS credential chain
+	UseDefaultChain bool
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// AssumeRoleARN is a role to assume using the static or default credentials
+	AssumeRoleARN   string
+	ExternalID      string
+	RoleSessionName string
+}
+
+// AWSSettings locate the AWS APIs
+type AWSSettings struct {
+	Region string
+	// CustomEndpointURL replaces the endpoint of the RDS API only, such as with a FIPS endpoint or LocalStack.
+	// STS, ElastiCache and DocumentDB keep the endpoints of the region.
+	CustomEndpointURL string
+	Credentials       Credentials
+}
+
+func loadAWSConfig(ctx context.Context, settings AWSSettings) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{config.WithRegion(settings.Region)}
+	creds := settings.Credentials
+	if !creds.UseDefaultChain {
+		opts = append(opts, config.WithCredentialsProvider(
+			aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken)),
+		))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	if creds.AssumeRoleARN != "" {
+		cfg.Credentials = aws.NewCredentialsCache(
+			stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), creds.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
+				if creds.ExternalID != "" {
+					o.ExternalID = aws.String(creds.ExternalID)
+				}
+				if creds.RoleSessionName != "" {
+					o.RoleSessionName = creds.RoleSessionName
+				}
+			}),
+		)
+	}
+
+	return cfg, nil
+}
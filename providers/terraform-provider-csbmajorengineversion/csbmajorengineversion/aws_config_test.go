@@ -0,0 +1,179 @@
+package csbmajorengineversion_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+// awsRequest records what the stand-ins for the AWS APIs received
+type awsRequest struct {
+	service       string
+	action        string
+	authorization string
+	securityToken string
+}
+
+const (
+	describeDBEngineVersionsResponse = `<DescribeDBEngineVersionsResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+  <DescribeDBEngineVersionsResult>
+    <DBEngineVersions>
+      <DBEngineVersion>
+        <Engine>postgres</Engine>
+        <EngineVersion>15.7</EngineVersion>
+        <MajorEngineVersion>15</MajorEngineVersion>
+        <Status>available</Status>
+        <DBParameterGroupFamily>postgres15</DBParameterGroupFamily>
+      </DBEngineVersion>
+    </DBEngineVersions>
+  </DescribeDBEngineVersionsResult>
+</DescribeDBEngineVersionsResponse>`
+
+	describeDBMajorEngineVersionsResponse = `<DescribeDBMajorEngineVersionsResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+  <DescribeDBMajorEngineVersionsResult>
+    <DBMajorEngineVersions/>
+  </DescribeDBMajorEngineVersionsResult>
+</DescribeDBMajorEngineVersionsResponse>`
+
+	assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASIAASSUMEDROLE</AccessKeyId>
+      <SecretAccessKey>assumed-secret</SecretAccessKey>
+      <SessionToken>assumed-token</SessionToken>
+      <Expiration>2100-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/csb/csb</Arn>
+      <AssumedRoleId>AROAEXAMPLE:csb</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+)
+
+var _ = Describe("AWS settings", func() {
+	var (
+		rdsServer *httptest.Server
+		mutex     sync.Mutex
+		requests  []awsRequest
+	)
+
+	// standIn answers the actions of one AWS service, and records every request it receives
+	standIn := func(service string, responses map[string]string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			mutex.Lock()
+			requests = append(requests, awsRequest{
+				service:       service,
+				action:        r.Form.Get("Action"),
+				authorization: r.Header.Get("Authorization"),
+				securityToken: r.Header.Get("X-Amz-Security-Token"),
+			})
+			mutex.Unlock()
+
+			w.Header().Set("Content-Type", "text/xml")
+			response, ok := responses[r.Form.Get("Action")]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, response)
+		}))
+		DeferCleanup(server.Close)
+		return server
+	}
+
+	BeforeEach(func() {
+		requests = nil
+		rdsServer = standIn("rds", map[string]string{
+			"DescribeDBEngineVersions":      describeDBEngineVersionsResponse,
+			"DescribeDBMajorEngineVersions": describeDBMajorEngineVersionsResponse,
+		})
+		// The endpoint of every service, which the custom endpoint must only replace for RDS
+		GinkgoT().Setenv("AWS_ENDPOINT_URL", standIn("sts", map[string]string{"AssumeRole": assumeRoleResponse}).URL)
+	})
+
+	configure := func(raw map[string]any) (any, diag.Diagnostics) {
+		raw["engine"] = "postgres"
+		raw["region"] = "us-gov-west-1"
+		raw["custom_endpoint_url"] = rdsServer.URL
+		return csbmajorengineversion.ProviderConfigureContext(context.TODO(), schema.TestResourceDataRaw(GinkgoT(), csbmajorengineversion.ProviderSchema(), raw))
+	}
+
+	readMajorVersion := func(meta any) string {
+		resource := csbmajorengineversion.DataSourceMajorEngineVersion()
+		data := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, map[string]any{"engine_version": "15.7"})
+		Expect(resource.ReadContext(context.TODO(), data, meta)).To(BeEmpty())
+		return data.Get("major_version").(string)
+	}
+
+	requestFor := func(action string) awsRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, r := range requests {
+			if r.action == action {
+				return r
+			}
+		}
+		Fail(fmt.Sprintf("no %s request was received", action))
+		return awsRequest{}
+	}
+
+	It("sends the RDS requests to the custom endpoint with the static credentials", func() {
+		meta, d := configure(map[string]any{"access_key_id": "AKIASTATIC", "secret_access_key": "secret", "session_token": "static-token"})
+		Expect(d).To(BeEmpty())
+
+		Expect(readMajorVersion(meta)).To(Equal("15"))
+		request := requestFor("DescribeDBEngineVersions")
+		Expect(request.service).To(Equal("rds"))
+		Expect(request.authorization).To(ContainSubstring("Credential=AKIASTATIC/"))
+		Expect(request.authorization).To(ContainSubstring("/us-gov-west-1/rds/"))
+		Expect(request.securityToken).To(Equal("static-token"))
+	})
+
+	It("assumes a role", func() {
+		meta, d := configure(map[string]any{
+			"access_key_id":     "AKIASTATIC",
+			"secret_access_key": "secret",
+			"assume_role_arn":   "arn:aws:iam::123456789012:role/csb",
+			"external_id":       "csb-external-id",
+		})
+		Expect(d).To(BeEmpty())
+
+		Expect(readMajorVersion(meta)).To(Equal("15"))
+		assumeRole := requestFor("AssumeRole")
+		Expect(assumeRole.service).To(Equal("sts"), "the custom endpoint of RDS must not receive STS requests")
+		Expect(assumeRole.authorization).To(ContainSubstring("Credential=AKIASTATIC/"))
+		request := requestFor("DescribeDBEngineVersions")
+		Expect(request.authorization).To(ContainSubstring("Credential=ASIAASSUMEDROLE/"))
+		Expect(request.securityToken).To(Equal("assumed-token"))
+	})
+
+	It("uses the default credential chain", func() {
+		GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "AKIADEFAULTCHAIN")
+		GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		GinkgoT().Setenv("AWS_SESSION_TOKEN", "")
+		GinkgoT().Setenv("AWS_PROFILE", "")
+
+		meta, d := configure(map[string]any{"use_default_credentials": true})
+		Expect(d).To(BeEmpty())
+
+		Expect(readMajorVersion(meta)).To(Equal("15"))
+		Expect(requestFor("DescribeDBEngineVersions").authorization).To(ContainSubstring("Credential=AKIADEFAULTCHAIN/"))
+	})
+
+	It("does not combine the default credential chain with static credentials", func() {
+		_, d := configure(map[string]any{"use_default_credentials": true, "access_key_id": "AKIASTATIC"})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal(`"use_default_credentials" cannot be combined with static credentials`))
+	})
+})
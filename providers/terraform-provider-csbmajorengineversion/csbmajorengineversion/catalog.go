@@ -0,0 +1,189 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+//go:embed catalog/engine_versions.json
+var embeddedCatalog []byte
+
+// Catalog is a snapshot of the engine versions, used when the AWS APIs cannot be reached
+type Catalog struct {
+	GeneratedAt time.Time                `json:"generated_at"`
+	Region      string                   `json:"region"`
+	Engines     map[string]CatalogEngine `json:"engines"`
+}
+
+type CatalogEngine struct {
+	DefaultVersion string           `json:"default_version"`
+	Versions       []CatalogVersion `json:"versions"`
+	// EndOfStandardSupport maps major versions to the date when standard support ends, in the YYYY-MM-DD format
+	EndOfStandardSupport map[string]string `json:"end_of_standard_support,omitempty"`
+	// ParameterGroups maps the names of parameter groups to their family
+	ParameterGroups map[string]string `json:"parameter_groups,omitempty"`
+}
+
+type CatalogVersion struct {
+	Version              string   `json:"version"`
+	MajorVersion         string   `json:"major_version"`
+	Status               string   `json:"status"`
+	ParameterGroupFamily string   `json:"parameter_group_family"`
+	UpgradeTargets       []string `json:"upgrade_targets,omitempty"`
+	InstanceClasses      []string `json:"instance_classes,omitempty"`
+}
+
+// LoadCatalog reads an operator provided catalog, or the catalog embedded in the provider when the path is empty
+func LoadCatalog(path string) (Catalog, error) {
+	data := embeddedCatalog
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return Catalog{}, fmt.Errorf("error reading the engine version catalog: %w", err)
+		}
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return Catalog{}, fmt.Errorf("error parsing the engine version catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// BuildCatalog looks up every version of the engines, so that the catalog can be regenerated from the AWS APIs
+func BuildCatalog(ctx context.Context, cfg aws.Config, engines []string) (Catalog, error) {
+	catalog := Catalog{GeneratedAt: time.Now().UTC().Truncate(time.Second), Region: cfg.Region, Engines: make(map[string]CatalogEngine)}
+	for _, engine := range engines {
+		entry, err := buildCatalogEngine(ctx, newVersionLookup(engine, cfg, ""))
+		if err != nil {
+			return Catalog{}, fmt.Errorf("error looking up engine %s: %w", engine, err)
+		}
+		catalog.Engines[engine] = entry
+	}
+	return catalog, nil
+}
+
+func buildCatalogEngine(ctx context.Context, lookup VersionLookup) (CatalogEngine, error) {
+	versions, err := lookup.EngineVersions(ctx, "")
+	if err != nil {
+		return CatalogEngine{}, err
+	}
+
+	defaultVersion, err := lookup.DefaultVersion(ctx)
+	if err != nil {
+		return CatalogEngine{}, err
+	}
+
+	instanceClasses, err := lookup.InstanceClasses(ctx)
+	if err != nil {
+		return CatalogEngine{}, err
+	}
+
+	entry := CatalogEngine{
+		DefaultVersion:       defaultVersion,
+		EndOfStandardSupport: make(map[string]string),
+		ParameterGroups:      make(map[string]string),
+	}
+	for _, version := range versions {
+		entry.Versions = append(entry.Versions, CatalogVersion{
+			Version:              version.Version,
+			MajorVersion:         version.MajorVersion,
+			Status:               version.Status,
+			ParameterGroupFamily: version.ParameterGroupFamily,
+			UpgradeTargets:       version.UpgradeTargets,
+			InstanceClasses:      instanceClasses[version.Version],
+		})
+
+		if _, ok := entry.EndOfStandardSupport[version.MajorVersion]; !ok {
+			end, err := lookup.EndOfStandardSupport(ctx, version.MajorVersion)
+			if err != nil {
+				return CatalogEngine{}, err
+			}
+			if !end.IsZero() {
+				entry.EndOfStandardSupport[version.MajorVersion] = end.Format(time.DateOnly)
+			}
+		}
+
+		// Only the default parameter groups exist in every account
+		name := "default." + version.ParameterGroupFamily
+		if _, ok := entry.ParameterGroups[name]; !ok && version.ParameterGroupFamily != "" {
+			if family, err := lookup.ParameterGroupFamily(ctx, name); err == nil {
+				entry.ParameterGroups[name] = family
+			}
+		}
+	}
+	return entry, nil
+}
+
+// catalogLookup answers from a catalog instead of the AWS APIs
+type catalogLookup struct {
+	engine string
+	entry  CatalogEngine
+}
+
+var _ VersionLookup = &catalogLookup{}
+
+func newCatalogLookup(engine string, catalog Catalog) (*catalogLookup, error) {
+	entry, ok := catalog.Engines[engine]
+	if !ok {
+		return nil, fmt.Errorf("engine %s is not in the engine version catalog", engine)
+	}
+	return &catalogLookup{engine: engine, entry: entry}, nil
+}
+
+// EngineVersions matches versions the way the AWS APIs do, so that "8.0" matches "8.0.32"
+func (c *catalogLookup) EngineVersions(_ context.Context, engineVersion string) ([]EngineVersion, error) {
+	var versions []EngineVersion
+	for _, version := range c.entry.Versions {
+		if engineVersion != "" && version.Version != engineVersion && !strings.HasPrefix(version.Version, engineVersion+".") {
+			continue
+		}
+		versions = append(versions, EngineVersion{
+			Engine:               c.engine,
+			Version:              version.Version,
+			MajorVersion:         version.MajorVersion,
+			Status:               version.Status,
+			ParameterGroupFamily: version.ParameterGroupFamily,
+			UpgradeTargets:       slices.Clone(version.UpgradeTargets),
+		})
+	}
+	return versions, nil
+}
+
+func (c *catalogLookup) DefaultVersion(context.Context) (string, error) {
+	return c.entry.DefaultVersion, nil
+}
+
+func (c *catalogLookup) InstanceClasses(context.Context) (map[string][]string, error) {
+	classes := make(map[string][]string, len(c.entry.Versions))
+	for _, version := range c.entry.Versions {
+		if len(version.InstanceClasses) > 0 {
+			classes[version.Version] = slices.Clone(version.InstanceClasses)
+		}
+	}
+	return classes, nil
+}
+
+func (c *catalogLookup) ParameterGroupFamily(_ context.Context, parameterGroupName string) (string, error) {
+	family, ok := c.entry.ParameterGroups[parameterGroupName]
+	if !ok {
+		return "", fmt.Errorf("parameter group %s does not exist in the engine version catalog", parameterGroupName)
+	}
+	return family, nil
+}
+
+func (c *catalogLookup) EndOfStandardSupport(_ context.Context, majorVersion string) (time.Time, error) {
+	date, ok := c.entry.EndOfStandardSupport[majorVersion]
+	if !ok {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, date)
+}
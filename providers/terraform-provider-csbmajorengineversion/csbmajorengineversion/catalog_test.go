@@ -0,0 +1,201 @@
+package csbmajorengineversion_test
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+const testCatalogPath = "testdata/catalog.json"
+
+var _ = Describe("Offline mode", func() {
+
+	DescribeTable("Major engine version can be obtained from the catalog", func(engine, engineVersion, majorVersion string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider(engine, nil), map[string]any{"engine_version": engineVersion})
+		Expect(d.HasError()).To(BeFalse())
+		Expect(data.Id()).To(Equal("version"))
+		Expect(data.Get("major_version")).To(Equal(majorVersion))
+	},
+		Entry("postgres 14.12", "postgres", "14.12", "14"),
+		Entry("postgres 14", "postgres", "14", "14"),
+		Entry("postgres 16.3", "postgres", "16.3", "16"),
+		Entry("redis 6.2.6", "redis", "6.2.6", "6"),
+		Entry("redis 7.1", "redis", "7.1", "7"),
+	)
+
+	It("reports versions that are not in the catalog", func() {
+		_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "invalid_engine_version"})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(ContainSubstring("API does not return any db engine version - engine postgres - engine version invalid_engine_version"))
+	})
+
+	It("exposes the status and the parameter group family", func() {
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "15.7"})
+		Expect(d).To(BeEmpty())
+		Expect(data.Get("status")).To(Equal("available"))
+		Expect(data.Get("parameter_group_family")).To(Equal("postgres15"))
+		Expect(data.Get("end_of_standard_support")).To(BeEmpty())
+	})
+
+	It("resolves a loose version to the highest available version", func() {
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "14"})
+		Expect(d).To(BeEmpty())
+		Expect(data.Get("resolved_engine_version")).To(Equal("14.12"))
+		Expect(data.Get("is_default")).To(BeFalse())
+
+		data, d = readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "16"})
+		Expect(d).To(BeEmpty())
+		Expect(data.Get("resolved_engine_version")).To(Equal("16.3"))
+		Expect(data.Get("is_default")).To(BeTrue())
+	})
+
+	Context("deprecation and end of support", func() {
+		It("warns about deprecated versions", func() {
+			data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "14.2"})
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal("postgres version 14.2 is deprecated"))
+			Expect(data.Get("status")).To(Equal("deprecated"))
+		})
+
+		It("warns about versions past the end of standard support", func() {
+			data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "13.15"})
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal("postgres version 13.15 reached the end of RDS standard support on 2020-02-28"))
+			Expect(data.Get("end_of_standard_support")).To(Equal("2020-02-28"))
+		})
+
+		It("warns about versions close to the end of standard support", func() {
+			meta := offlineProvider("postgres", map[string]any{"end_of_support_warning_days": 100000})
+			_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), meta, map[string]any{"engine_version": "14.12"})
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal("postgres version 14.12 reaches the end of RDS standard support on 2100-02-28"))
+		})
+
+		It("does not warn about versions far from the end of standard support", func() {
+			_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "14.12"})
+			Expect(d).To(BeEmpty())
+		})
+
+		It("fails on deprecated versions when the policy requires it", func() {
+			meta := offlineProvider("postgres", map[string]any{"fail_on_deprecated_version": true})
+			for _, version := range []string{"14.2", "13.15"} {
+				_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), meta, map[string]any{"engine_version": version})
+				Expect(d.HasError()).To(BeTrue(), version)
+			}
+		})
+	})
+
+	Context("parameter groups", func() {
+		It("accepts a parameter group of the right family", func() {
+			_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "15.7", "parameter_group_name": "default.postgres15"})
+			Expect(d).To(BeEmpty())
+		})
+
+		It("rejects a parameter group of another family", func() {
+			_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "14.12", "parameter_group_name": "default.postgres15"})
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(Equal("parameter group default.postgres15 has family postgres15, but engine postgres requires family postgres14"))
+		})
+
+		It("rejects an unknown parameter group", func() {
+			_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("postgres", nil), map[string]any{"engine_version": "14.12", "parameter_group_name": "csb-does-not-exist"})
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(ContainSubstring("csb-does-not-exist does not exist"))
+		})
+	})
+
+	It("lists the versions of a major version", func() {
+		data, d := readDataSource(csbmajorengineversion.DataSourceEngineVersions(), offlineProvider("postgres", nil), map[string]any{"major_version": "16"})
+		Expect(d).To(BeEmpty())
+		Expect(data.Id()).To(Equal("postgres"))
+		Expect(data.Get("versions")).To(ConsistOf(map[string]any{
+			"engine_version":         "16.3",
+			"major_version":          "16",
+			"status":                 "available",
+			"is_default":             true,
+			"parameter_group_family": "postgres16",
+			"valid_upgrade_targets":  []any{},
+			"instance_classes":       []any{"db.m6i.large", "db.t4g.micro"},
+		}))
+	})
+
+	DescribeTable("Upgrade paths are resolved from the catalog", func(currentVersion, targetVersion string, path []any, expectedErrorMessage string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceUpgradePath(), offlineProvider("postgres", nil), map[string]any{
+			"current_engine_version": currentVersion,
+			"target_engine_version":  targetVersion,
+		})
+		switch expectedErrorMessage {
+		case "":
+			Expect(d).To(BeEmpty())
+			Expect(data.Get("upgrade_path")).To(Equal(path))
+		default:
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(Equal(expectedErrorMessage))
+		}
+	},
+		Entry("same version", "15.7", "15.7", []any{}, ""),
+		Entry("direct upgrade", "15.7", "16.3", []any{"16.3"}, ""),
+		Entry("several hops", "13.15", "16.3", []any{"14.12", "15.7", "16.3"}, ""),
+		Entry("downgrade", "16.3", "15.7", nil, "no upgrade path from postgres version 16.3 to 15.7: version 16.3 cannot be upgraded"),
+		Entry("unreachable version", "14.12", "14.2", nil, "no upgrade path from postgres version 14.12 to 14.2"),
+	)
+
+	It("uses the embedded catalog by default", func() {
+		meta, d := configureProvider(map[string]any{"engine": "postgres", "region": "us-west-2", "offline": true})
+		Expect(d).To(BeEmpty())
+
+		catalog, err := csbmajorengineversion.LoadCatalog("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(catalog.Engines).To(HaveKey("postgres"))
+
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), meta, map[string]any{"engine_version": catalog.Engines["postgres"].DefaultVersion})
+		Expect(d.HasError()).To(BeFalse())
+		Expect(data.Get("major_version")).NotTo(BeEmpty())
+	})
+
+	It("reports engines that are not in the catalog", func() {
+		_, d := configureProvider(map[string]any{"engine": "oracle-ee", "region": "us-west-2", "catalog_path": testCatalogPath})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("engine oracle-ee is not in the engine version catalog"))
+	})
+
+	It("reports catalogs that cannot be read", func() {
+		_, d := configureProvider(map[string]any{"engine": "postgres", "region": "us-west-2", "catalog_path": "testdata/missing.json"})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(ContainSubstring("error reading the engine version catalog"))
+	})
+
+	It("requires credentials when it is not offline", func() {
+		_, d := configureProvider(map[string]any{"engine": "postgres", "region": "us-west-2"})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal(`"access_key_id" and "secret_access_key" are required unless "use_default_credentials" is set or the provider is offline`))
+	})
+})
+
+func configureProvider(raw map[string]any) (any, diag.Diagnostics) {
+	return csbmajorengineversion.ProviderConfigureContext(context.TODO(), schema.TestResourceDataRaw(GinkgoT(), csbmajorengineversion.ProviderSchema(), raw))
+}
+
+// offlineProvider configures the provider for the engine with the test catalog, so that no AWS API is called
+func offlineProvider(engine string, extra map[string]any) any {
+	raw := map[string]any{"engine": engine, "region": "us-west-2", "catalog_path": testCatalogPath}
+	for k, v := range extra {
+		raw[k] = v
+	}
+	meta, d := configureProvider(raw)
+	Expect(d).To(BeEmpty())
+	return meta
+}
+
+func readDataSource(resource *schema.Resource, meta any, raw map[string]any) (*schema.ResourceData, diag.Diagnostics) {
+	data := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, raw)
+	return data, resource.ReadContext(context.TODO(), data, meta)
+}
@@ -0,0 +1,373 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbmajorengineversionfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+type FakeRDSClient struct {
+	DescribeDBEngineVersionsStub        func(context.Context, *rds.DescribeDBEngineVersionsInput, ...func(*rds.Options)) (*rds.DescribeDBEngineVersionsOutput, error)
+	describeDBEngineVersionsMutex       sync.RWMutex
+	describeDBEngineVersionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBEngineVersionsInput
+		arg3 []func(*rds.Options)
+	}
+	describeDBEngineVersionsReturns struct {
+		result1 *rds.DescribeDBEngineVersionsOutput
+		result2 error
+	}
+	describeDBEngineVersionsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeDBEngineVersionsOutput
+		result2 error
+	}
+	DescribeDBMajorEngineVersionsStub        func(context.Context, *rds.DescribeDBMajorEngineVersionsInput, ...func(*rds.Options)) (*rds.DescribeDBMajorEngineVersionsOutput, error)
+	describeDBMajorEngineVersionsMutex       sync.RWMutex
+	describeDBMajorEngineVersionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBMajorEngineVersionsInput
+		arg3 []func(*rds.Options)
+	}
+	describeDBMajorEngineVersionsReturns struct {
+		result1 *rds.DescribeDBMajorEngineVersionsOutput
+		result2 error
+	}
+	describeDBMajorEngineVersionsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeDBMajorEngineVersionsOutput
+		result2 error
+	}
+	DescribeDBParameterGroupsStub        func(context.Context, *rds.DescribeDBParameterGroupsInput, ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error)
+	describeDBParameterGroupsMutex       sync.RWMutex
+	describeDBParameterGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBParameterGroupsInput
+		arg3 []func(*rds.Options)
+	}
+	describeDBParameterGroupsReturns struct {
+		result1 *rds.DescribeDBParameterGroupsOutput
+		result2 error
+	}
+	describeDBParameterGroupsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeDBParameterGroupsOutput
+		result2 error
+	}
+	DescribeOrderableDBInstanceOptionsStub        func(context.Context, *rds.DescribeOrderableDBInstanceOptionsInput, ...func(*rds.Options)) (*rds.DescribeOrderableDBInstanceOptionsOutput, error)
+	describeOrderableDBInstanceOptionsMutex       sync.RWMutex
+	describeOrderableDBInstanceOptionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeOrderableDBInstanceOptionsInput
+		arg3 []func(*rds.Options)
+	}
+	describeOrderableDBInstanceOptionsReturns struct {
+		result1 *rds.DescribeOrderableDBInstanceOptionsOutput
+		result2 error
+	}
+	describeOrderableDBInstanceOptionsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeOrderableDBInstanceOptionsOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersions(arg1 context.Context, arg2 *rds.DescribeDBEngineVersionsInput, arg3 ...func(*rds.Options)) (*rds.DescribeDBEngineVersionsOutput, error) {
+	fake.describeDBEngineVersionsMutex.Lock()
+	ret, specificReturn := fake.describeDBEngineVersionsReturnsOnCall[len(fake.describeDBEngineVersionsArgsForCall)]
+	fake.describeDBEngineVersionsArgsForCall = append(fake.describeDBEngineVersionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBEngineVersionsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeDBEngineVersionsStub
+	fakeReturns := fake.describeDBEngineVersionsReturns
+	fake.recordInvocation("DescribeDBEngineVersions", []interface{}{arg1, arg2, arg3})
+	fake.describeDBEngineVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersionsCallCount() int {
+	fake.describeDBEngineVersionsMutex.RLock()
+	defer fake.describeDBEngineVersionsMutex.RUnlock()
+	return len(fake.describeDBEngineVersionsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersionsCalls(stub func(context.Context, *rds.DescribeDBEngineVersionsInput, ...func(*rds.Options)) (*rds.DescribeDBEngineVersionsOutput, error)) {
+	fake.describeDBEngineVersionsMutex.Lock()
+	defer fake.describeDBEngineVersionsMutex.Unlock()
+	fake.DescribeDBEngineVersionsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersionsArgsForCall(i int) (context.Context, *rds.DescribeDBEngineVersionsInput, []func(*rds.Options)) {
+	fake.describeDBEngineVersionsMutex.RLock()
+	defer fake.describeDBEngineVersionsMutex.RUnlock()
+	argsForCall := fake.describeDBEngineVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersionsReturns(result1 *rds.DescribeDBEngineVersionsOutput, result2 error) {
+	fake.describeDBEngineVersionsMutex.Lock()
+	defer fake.describeDBEngineVersionsMutex.Unlock()
+	fake.DescribeDBEngineVersionsStub = nil
+	fake.describeDBEngineVersionsReturns = struct {
+		result1 *rds.DescribeDBEngineVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBEngineVersionsReturnsOnCall(i int, result1 *rds.DescribeDBEngineVersionsOutput, result2 error) {
+	fake.describeDBEngineVersionsMutex.Lock()
+	defer fake.describeDBEngineVersionsMutex.Unlock()
+	fake.DescribeDBEngineVersionsStub = nil
+	if fake.describeDBEngineVersionsReturnsOnCall == nil {
+		fake.describeDBEngineVersionsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeDBEngineVersionsOutput
+			result2 error
+		})
+	}
+	fake.describeDBEngineVersionsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeDBEngineVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersions(arg1 context.Context, arg2 *rds.DescribeDBMajorEngineVersionsInput, arg3 ...func(*rds.Options)) (*rds.DescribeDBMajorEngineVersionsOutput, error) {
+	fake.describeDBMajorEngineVersionsMutex.Lock()
+	ret, specificReturn := fake.describeDBMajorEngineVersionsReturnsOnCall[len(fake.describeDBMajorEngineVersionsArgsForCall)]
+	fake.describeDBMajorEngineVersionsArgsForCall = append(fake.describeDBMajorEngineVersionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBMajorEngineVersionsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeDBMajorEngineVersionsStub
+	fakeReturns := fake.describeDBMajorEngineVersionsReturns
+	fake.recordInvocation("DescribeDBMajorEngineVersions", []interface{}{arg1, arg2, arg3})
+	fake.describeDBMajorEngineVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersionsCallCount() int {
+	fake.describeDBMajorEngineVersionsMutex.RLock()
+	defer fake.describeDBMajorEngineVersionsMutex.RUnlock()
+	return len(fake.describeDBMajorEngineVersionsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersionsCalls(stub func(context.Context, *rds.DescribeDBMajorEngineVersionsInput, ...func(*rds.Options)) (*rds.DescribeDBMajorEngineVersionsOutput, error)) {
+	fake.describeDBMajorEngineVersionsMutex.Lock()
+	defer fake.describeDBMajorEngineVersionsMutex.Unlock()
+	fake.DescribeDBMajorEngineVersionsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersionsArgsForCall(i int) (context.Context, *rds.DescribeDBMajorEngineVersionsInput, []func(*rds.Options)) {
+	fake.describeDBMajorEngineVersionsMutex.RLock()
+	defer fake.describeDBMajorEngineVersionsMutex.RUnlock()
+	argsForCall := fake.describeDBMajorEngineVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersionsReturns(result1 *rds.DescribeDBMajorEngineVersionsOutput, result2 error) {
+	fake.describeDBMajorEngineVersionsMutex.Lock()
+	defer fake.describeDBMajorEngineVersionsMutex.Unlock()
+	fake.DescribeDBMajorEngineVersionsStub = nil
+	fake.describeDBMajorEngineVersionsReturns = struct {
+		result1 *rds.DescribeDBMajorEngineVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBMajorEngineVersionsReturnsOnCall(i int, result1 *rds.DescribeDBMajorEngineVersionsOutput, result2 error) {
+	fake.describeDBMajorEngineVersionsMutex.Lock()
+	defer fake.describeDBMajorEngineVersionsMutex.Unlock()
+	fake.DescribeDBMajorEngineVersionsStub = nil
+	if fake.describeDBMajorEngineVersionsReturnsOnCall == nil {
+		fake.describeDBMajorEngineVersionsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeDBMajorEngineVersionsOutput
+			result2 error
+		})
+	}
+	fake.describeDBMajorEngineVersionsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeDBMajorEngineVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroups(arg1 context.Context, arg2 *rds.DescribeDBParameterGroupsInput, arg3 ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error) {
+	fake.describeDBParameterGroupsMutex.Lock()
+	ret, specificReturn := fake.describeDBParameterGroupsReturnsOnCall[len(fake.describeDBParameterGroupsArgsForCall)]
+	fake.describeDBParameterGroupsArgsForCall = append(fake.describeDBParameterGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBParameterGroupsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeDBParameterGroupsStub
+	fakeReturns := fake.describeDBParameterGroupsReturns
+	fake.recordInvocation("DescribeDBParameterGroups", []interface{}{arg1, arg2, arg3})
+	fake.describeDBParameterGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroupsCallCount() int {
+	fake.describeDBParameterGroupsMutex.RLock()
+	defer fake.describeDBParameterGroupsMutex.RUnlock()
+	return len(fake.describeDBParameterGroupsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroupsCalls(stub func(context.Context, *rds.DescribeDBParameterGroupsInput, ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error)) {
+	fake.describeDBParameterGroupsMutex.Lock()
+	defer fake.describeDBParameterGroupsMutex.Unlock()
+	fake.DescribeDBParameterGroupsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroupsArgsForCall(i int) (context.Context, *rds.DescribeDBParameterGroupsInput, []func(*rds.Options)) {
+	fake.describeDBParameterGroupsMutex.RLock()
+	defer fake.describeDBParameterGroupsMutex.RUnlock()
+	argsForCall := fake.describeDBParameterGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroupsReturns(result1 *rds.DescribeDBParameterGroupsOutput, result2 error) {
+	fake.describeDBParameterGroupsMutex.Lock()
+	defer fake.describeDBParameterGroupsMutex.Unlock()
+	fake.DescribeDBParameterGroupsStub = nil
+	fake.describeDBParameterGroupsReturns = struct {
+		result1 *rds.DescribeDBParameterGroupsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBParameterGroupsReturnsOnCall(i int, result1 *rds.DescribeDBParameterGroupsOutput, result2 error) {
+	fake.describeDBParameterGroupsMutex.Lock()
+	defer fake.describeDBParameterGroupsMutex.Unlock()
+	fake.DescribeDBParameterGroupsStub = nil
+	if fake.describeDBParameterGroupsReturnsOnCall == nil {
+		fake.describeDBParameterGroupsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeDBParameterGroupsOutput
+			result2 error
+		})
+	}
+	fake.describeDBParameterGroupsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeDBParameterGroupsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptions(arg1 context.Context, arg2 *rds.DescribeOrderableDBInstanceOptionsInput, arg3 ...func(*rds.Options)) (*rds.DescribeOrderableDBInstanceOptionsOutput, error) {
+	fake.describeOrderableDBInstanceOptionsMutex.Lock()
+	ret, specificReturn := fake.describeOrderableDBInstanceOptionsReturnsOnCall[len(fake.describeOrderableDBInstanceOptionsArgsForCall)]
+	fake.describeOrderableDBInstanceOptionsArgsForCall = append(fake.describeOrderableDBInstanceOptionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeOrderableDBInstanceOptionsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeOrderableDBInstanceOptionsStub
+	fakeReturns := fake.describeOrderableDBInstanceOptionsReturns
+	fake.recordInvocation("DescribeOrderableDBInstanceOptions", []interface{}{arg1, arg2, arg3})
+	fake.describeOrderableDBInstanceOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptionsCallCount() int {
+	fake.describeOrderableDBInstanceOptionsMutex.RLock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.RUnlock()
+	return len(fake.describeOrderableDBInstanceOptionsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptionsCalls(stub func(context.Context, *rds.DescribeOrderableDBInstanceOptionsInput, ...func(*rds.Options)) (*rds.DescribeOrderableDBInstanceOptionsOutput, error)) {
+	fake.describeOrderableDBInstanceOptionsMutex.Lock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.Unlock()
+	fake.DescribeOrderableDBInstanceOptionsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptionsArgsForCall(i int) (context.Context, *rds.DescribeOrderableDBInstanceOptionsInput, []func(*rds.Options)) {
+	fake.describeOrderableDBInstanceOptionsMutex.RLock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.RUnlock()
+	argsForCall := fake.describeOrderableDBInstanceOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptionsReturns(result1 *rds.DescribeOrderableDBInstanceOptionsOutput, result2 error) {
+	fake.describeOrderableDBInstanceOptionsMutex.Lock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.Unlock()
+	fake.DescribeOrderableDBInstanceOptionsStub = nil
+	fake.describeOrderableDBInstanceOptionsReturns = struct {
+		result1 *rds.DescribeOrderableDBInstanceOptionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeOrderableDBInstanceOptionsReturnsOnCall(i int, result1 *rds.DescribeOrderableDBInstanceOptionsOutput, result2 error) {
+	fake.describeOrderableDBInstanceOptionsMutex.Lock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.Unlock()
+	fake.DescribeOrderableDBInstanceOptionsStub = nil
+	if fake.describeOrderableDBInstanceOptionsReturnsOnCall == nil {
+		fake.describeOrderableDBInstanceOptionsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeOrderableDBInstanceOptionsOutput
+			result2 error
+		})
+	}
+	fake.describeOrderableDBInstanceOptionsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeOrderableDBInstanceOptionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.describeDBEngineVersionsMutex.RLock()
+	defer fake.describeDBEngineVersionsMutex.RUnlock()
+	fake.describeDBMajorEngineVersionsMutex.RLock()
+	defer fake.describeDBMajorEngineVersionsMutex.RUnlock()
+	fake.describeDBParameterGroupsMutex.RLock()
+	defer fake.describeDBParameterGroupsMutex.RUnlock()
+	fake.describeOrderableDBInstanceOptionsMutex.RLock()
+	defer fake.describeOrderableDBInstanceOptionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRDSClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbmajorengineversion.RDSClient = new(FakeRDSClient)
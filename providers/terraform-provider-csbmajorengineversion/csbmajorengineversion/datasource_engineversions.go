@@ -0,0 +1,99 @@
+package csbmajorengineversion
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceEngineVersions() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			majorVersionKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Only return the versions with this major version",
+			},
+			versionsKey: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						engineVersionKey:        {Type: schema.TypeString, Computed: true},
+						majorVersionKey:         {Type: schema.TypeString, Computed: true},
+						statusKey:               {Type: schema.TypeString, Computed: true},
+						isDefaultKey:            {Type: schema.TypeBool, Computed: true},
+						parameterGroupFamilyKey: {Type: schema.TypeString, Computed: true},
+						validUpgradeTargetsKey: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						instanceClassesKey: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceEngineVersionsRead,
+		Description: "Returns every available version of the engine",
+	}
+}
+
+func dataSourceEngineVersionsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	descriptor := meta.(*engineDescriptor)
+
+	engineVersions, err := descriptor.describeEngineVersions(ctx, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defaultVersion, err := descriptor.defaultEngineVersion(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceClasses, err := descriptor.instanceClasses(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	majorVersion := d.Get(majorVersionKey).(string)
+	versions := make([]map[string]any, 0, len(engineVersions))
+	for _, version := range engineVersions {
+		if majorVersion != "" && version.MajorVersion != majorVersion {
+			continue
+		}
+		versions = append(versions, describeEngineVersion(version, defaultVersion, instanceClasses))
+	}
+
+	d.SetId(descriptor.engine)
+
+	tflog.Debug(ctx, "Setting DB engine versions", map[string]any{
+		"engine":   descriptor.engine,
+		"versions": len(versions),
+	})
+	if err := d.Set(versionsKey, versions); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func describeEngineVersion(version EngineVersion, defaultVersion string, instanceClasses map[string][]string) map[string]any {
+	return map[string]any{
+		engineVersionKey:        version.Version,
+		majorVersionKey:         version.MajorVersion,
+		statusKey:               version.Status,
+		isDefaultKey:            version.Version == defaultVersion,
+		parameterGroupFamilyKey: version.ParameterGroupFamily,
+		validUpgradeTargetsKey:  version.UpgradeTargets,
+		instanceClassesKey:      instanceClasses[version.Version],
+	}
+}
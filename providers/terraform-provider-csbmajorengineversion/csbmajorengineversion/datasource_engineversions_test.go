@@ -0,0 +1,24 @@
+package csbmajorengineversion_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+var _ = Describe("Engine versions data source", func() {
+	DescribeTable("Engine versions can be listed", func(engine, majorVersion, parameterGroupFamily string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceEngineVersions(), offlineProvider(engine, nil), map[string]any{"major_version": majorVersion})
+		Expect(d.HasError()).To(BeFalse())
+		Expect(data.Id()).To(Equal(engine))
+		Expect(data.Get("versions")).NotTo(BeEmpty())
+		Expect(data.Get("versions.0.major_version")).To(Equal(majorVersion))
+		Expect(data.Get("versions.0.parameter_group_family")).To(Equal(parameterGroupFamily))
+		Expect(data.Get("versions.0.engine_version")).NotTo(BeEmpty())
+		Expect(data.Get("versions.0.status")).NotTo(BeEmpty())
+	},
+		Entry("postgres 15", "postgres", "15", "postgres15"),
+		Entry("mysql 8.0", "mysql", "8.0", "mysql8.0"),
+	)
+})
@@ -2,6 +2,7 @@ package csbmajorengineversion
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,6 +22,36 @@ func DataSourceMajorEngineVersion() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			parameterGroupFamilyKey: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			statusKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the version: available or deprecated",
+			},
+			endOfStandardSupportKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when RDS standard support ends for the major version, if published",
+			},
+			resolvedEngineVersionKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The exact engine version that best matches engine_version",
+			},
+			isDefaultKey: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the resolved version is used when no version is specified",
+			},
+			parameterGroupNameKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Fail if this DB parameter group does not belong to the parameter group family of the version",
+			},
 		},
 		ReadContext: resourceMajorEngineVersionRead,
 		Description: "Returns major engine version value",
@@ -31,20 +62,65 @@ func resourceMajorEngineVersionRead(ctx context.Context, d *schema.ResourceData,
 	descriptor := meta.(*engineDescriptor)
 
 	engineVersion := d.Get(engineVersionKey).(string)
-	majorEngineVersion, err := descriptor.Describe(ctx, engineVersion)
+	version, err := descriptor.describeEngineVersion(ctx, engineVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
+	majorEngineVersion := version.MajorVersion
+	parameterGroupFamily := version.ParameterGroupFamily
+	if parameterGroupName, ok := d.GetOk(parameterGroupNameKey); ok {
+		if err := descriptor.CheckParameterGroup(ctx, parameterGroupName.(string), parameterGroupFamily); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	defaultVersion, err := descriptor.defaultEngineVersion(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
+	endOfSupport, err := descriptor.EndOfStandardSupport(ctx, majorEngineVersion)
+	if err != nil {
+		// Older policies may not allow the lifecycle to be described, which should not prevent provisioning
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "could not check the end of RDS standard support",
+			Detail:   err.Error(),
+		})
+	}
+	diags = append(diags, descriptor.policy.check(descriptor.engine, version, endOfSupport, time.Now())...)
+	if diags.HasError() {
+		return diags
+	}
+
 	d.SetId("version")
 
 	tflog.Debug(ctx, "Setting Major DB engine version", map[string]any{
-		"major_engine_version": majorEngineVersion,
+		"major_engine_version":    majorEngineVersion,
+		"resolved_engine_version": version.Version,
+		"status":                  version.Status,
 	})
 	if err := d.Set(majorVersionKey, majorEngineVersion); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
-	return nil
-
+	if err := d.Set(parameterGroupFamilyKey, parameterGroupFamily); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set(statusKey, version.Status); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set(resolvedEngineVersionKey, version.Version); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set(isDefaultKey, version.Version == defaultVersion); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if !endOfSupport.IsZero() {
+		if err := d.Set(endOfStandardSupportKey, endOfSupport.Format(time.DateOnly)); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	return diags
 }
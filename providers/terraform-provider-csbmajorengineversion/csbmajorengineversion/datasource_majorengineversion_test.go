@@ -1,136 +1,86 @@
 package csbmajorengineversion_test
 
 import (
-	"errors"
-	"fmt"
-	"os"
-	"strings"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
 )
 
-const (
-	accessKeyID     = "AWS_ACCESS_KEY_ID"
-	secretAccessKey = "AWS_SECRET_ACCESS_KEY"
-	providerName    = "csbmajorengineversion"
-)
-
-var (
-	tfStateDataResourceName = fmt.Sprintf("data.%s.major_version", csbmajorengineversion.DataResourceNameKey)
-)
-
 var _ = Describe("Provider", func() {
-	var region = "us-west-2"
-	DescribeTable("Major engine version can be obtained", func(engine, engineVersion, majorVersion, expectedErrorMessage string) {
-		provider := initTestProvider()
-		resource.Test(GinkgoT(), resource.TestCase{
-			IsUnitTest:        true,
-			ProviderFactories: getTestProviderFactories(provider),
-			PreCheck: func() {
-				failIfEnvEmpty(accessKeyID)
-				failIfEnvEmpty(secretAccessKey)
-			},
-			Steps: []resource.TestStep{{
-				Config: testGetConfiguration(
-					os.Getenv(accessKeyID),
-					os.Getenv(secretAccessKey),
-					region,
-					engine,
-					engineVersion,
-				),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(tfStateDataResourceName, "engine_version", engineVersion),
-					resource.TestCheckResourceAttr(tfStateDataResourceName, "id", "version"),
-					resource.TestCheckResourceAttr(tfStateDataResourceName, "major_version", majorVersion),
-				),
-			}},
-			ErrorCheck: func(err error) error {
-				if err != nil {
-					if expectedErrorMessage == "" {
-						return err
-					}
-
-					if strings.Contains(err.Error(), expectedErrorMessage) {
-						return nil
-					}
-
-					return fmt.Errorf("Terraform provider %s error check fails: %w", providerName, err)
-				}
-				return nil
-			},
-		})
+	It("is valid", func() {
+		Expect(csbmajorengineversion.Provider().InternalValidate()).To(Succeed())
+	})
+
+	DescribeTable("Major engine version can be obtained", func(engine, engineVersion, majorVersion string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider(engine, nil), map[string]any{"engine_version": engineVersion})
+		Expect(d.HasError()).To(BeFalse())
+		Expect(data.Id()).To(Equal("version"))
+		Expect(data.Get("engine_version")).To(Equal(engineVersion))
+		Expect(data.Get("major_version")).To(Equal(majorVersion))
+		Expect(data.Get("parameter_group_family")).NotTo(BeEmpty())
+		Expect(data.Get("status")).NotTo(BeEmpty())
+		Expect(data.Get("resolved_engine_version")).NotTo(BeEmpty())
 	},
-		Entry("postgres 14", "postgres", "14.2", "14", ""), // --include-all option. 14.2 is not in the list
-		Entry("postgres 15", "postgres", "15.3", "15", ""),
-		Entry("aurora-mysql 8", "aurora-mysql", "8.0", "8.0", ""),
-		Entry("aurora-mysql 8.0.mysql_aurora.3.03.1", "aurora-mysql", "8.0.mysql_aurora.3.03.1", "8.0", ""),
-		Entry("aurora-mysql 5.7.mysql_aurora.2.07.10", "aurora-mysql", "5.7.mysql_aurora.2.07.10", "5.7", ""),
-		Entry("aurora-postgresql 14", "aurora-postgresql", "14", "14", ""),
-		Entry("aurora-postgresql 14.3", "aurora-postgresql", "14.3", "14", ""),
-		Entry("mysql 5.7", "mysql", "5.7", "5.7", ""),
-		Entry("mysql 5.7.42", "mysql", "5.7.42", "5.7", ""),
-		Entry("mysql 8.0", "mysql", "8.0", "8.0", ""),
-		Entry("mysql 8.0.32", "mysql", "8.0.32", "8.0", ""),
-		Entry("no engine", "", "", "8.0.32", `Error: expected "engine" to not be an empty string`),
-		Entry("no engine version", "mysql", "", "", `Error: expected "engine_version" to not be an empty string`),
-		Entry(
-			"aurora-postgresql 8.0.postgresql_aurora.3.02.0",
-			"aurora-postgresql",
-			"invalid_engine_version",
-			"xx",
-			"Error: invalid parameter combination. API does not return any db engine version - engine aurora-postgresql - engine version invalid_engine_version",
-		),
+		Entry("postgres 14", "postgres", "14.2", "14"),
+		Entry("postgres 15", "postgres", "15.7", "15"),
+		Entry("aurora-mysql 8", "aurora-mysql", "8.0", "8.0"),
+		Entry("aurora-mysql 8.0.mysql_aurora.3.03.1", "aurora-mysql", "8.0.mysql_aurora.3.03.1", "8.0"),
+		Entry("aurora-mysql 5.7.mysql_aurora.2.07.10", "aurora-mysql", "5.7.mysql_aurora.2.07.10", "5.7"),
+		Entry("aurora-postgresql 14", "aurora-postgresql", "14", "14"),
+		Entry("aurora-postgresql 14.3", "aurora-postgresql", "14.3", "14"),
+		Entry("mysql 5.7", "mysql", "5.7", "5.7"),
+		Entry("mysql 5.7.42", "mysql", "5.7.42", "5.7"),
+		Entry("mysql 8.0", "mysql", "8.0", "8.0"),
+		Entry("mysql 8.0.32", "mysql", "8.0.32", "8.0"),
+		Entry("redis 7.1", "redis", "7.1", "7"),
+		Entry("redis 6.2.6", "redis", "6.2.6", "6"),
+		Entry("docdb 5.0.0", "docdb", "5.0.0", "5.0"),
 	)
 
-})
-
-func initTestProvider() *schema.Provider {
-	testAccProvider := &schema.Provider{
-		Schema: csbmajorengineversion.ProviderSchema(),
-		DataSourcesMap: map[string]*schema.Resource{
-			csbmajorengineversion.DataResourceNameKey: csbmajorengineversion.DataSourceMajorEngineVersion(),
-		},
-		ConfigureContextFunc: csbmajorengineversion.ProviderConfigureContext,
-	}
-	err := testAccProvider.InternalValidate()
-	Expect(err).NotTo(HaveOccurred())
-
-	return testAccProvider
-}
-
-func getTestProviderFactories(provider *schema.Provider) map[string]func() (*schema.Provider, error) {
-	return map[string]func() (*schema.Provider, error){
-		providerName: func() (*schema.Provider, error) {
-			if provider == nil {
-				return provider, errors.New("provider cannot be nil")
-			}
+	It("reports versions that the engine does not have", func() {
+		_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider("aurora-postgresql", nil), map[string]any{"engine_version": "invalid_engine_version"})
+		Expect(d.HasError()).To(BeTrue())
+		Expect(d[0].Summary).To(Equal("invalid parameter combination. API does not return any db engine version - engine aurora-postgresql - engine version invalid_engine_version"))
+	})
 
-			return provider, nil
-		},
-	}
-}
+	DescribeTable("Configuration is validated", func(resource *schema.Resource, raw map[string]any, expectedErrorMessage string) {
+		d := resource.Validate(terraform.NewResourceConfigRaw(raw))
+		Expect(d).To(ContainElement(HaveField("Summary", HavePrefix(expectedErrorMessage))))
+	},
+		Entry("no engine", &schema.Resource{Schema: csbmajorengineversion.ProviderSchema()}, map[string]any{"engine": "", "region": "us-west-2"}, `expected "engine" to not be an empty string`),
+		Entry("no engine version", csbmajorengineversion.DataSourceMajorEngineVersion(), map[string]any{"engine_version": ""}, `expected "engine_version" to not be an empty string`),
+	)
 
-func testGetConfiguration(accessKeyID, secretAccessKey, region, engine, engineVersion string) string {
-	return fmt.Sprintf(`
-provider "csbmajorengineversion" {
-  engine 			= %[1]q
-  access_key_id     = %[2]q
-  secret_access_key = %[3]q
-  region            = %[4]q
-}
+	DescribeTable("Parameter groups are checked against the parameter group family", func(engine, engineVersion, parameterGroupName, parameterGroupFamily, expectedErrorMessage string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), offlineProvider(engine, nil), map[string]any{
+			"engine_version":       engineVersion,
+			"parameter_group_name": parameterGroupName,
+		})
+		switch expectedErrorMessage {
+		case "":
+			Expect(d.HasError()).To(BeFalse())
+			Expect(data.Get("parameter_group_family")).To(Equal(parameterGroupFamily))
+		default:
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(ContainSubstring(expectedErrorMessage))
+		}
+	},
+		Entry("matching family", "postgres", "15.7", "default.postgres15", "postgres15", ""),
+		Entry("different family", "postgres", "14.12", "default.postgres15", "", "parameter group default.postgres15 has family postgres15, but engine postgres requires family postgres14"),
+		Entry("missing parameter group", "mysql", "8.0.32", "csb-does-not-exist", "", "csb-does-not-exist"),
+	)
 
-data "csbmajorengineversion" "major_version" {
-  engine_version     = %[5]q
-}
-`, engine, accessKeyID, secretAccessKey, region, engineVersion)
-}
+	It("fails on deprecated versions when the policy requires it", func() {
+		meta := offlineProvider("postgres", map[string]any{"fail_on_deprecated_version": true})
 
-func failIfEnvEmpty(name string) {
-	value := os.Getenv(name)
-	Expect(value).NotTo(BeEmpty(), "environment variable %s must be set.", name)
-}
+		_, d := readDataSource(csbmajorengineversion.DataSourceMajorEngineVersion(), meta, map[string]any{"engine_version": "14.2"})
+		Expect(d).To(ContainElement(SatisfyAll(
+			HaveField("Severity", diag.Error),
+			HaveField("Summary", "postgres version 14.2 is deprecated"),
+		)))
+	})
+})
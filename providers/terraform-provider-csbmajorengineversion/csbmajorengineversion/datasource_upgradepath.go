@@ -0,0 +1,56 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceUpgradePath() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			currentEngineVersionKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			targetEngineVersionKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			upgradePathKey: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		ReadContext: dataSourceUpgradePathRead,
+		Description: "Returns the versions that an instance must be upgraded to in order to reach the target version",
+	}
+}
+
+func dataSourceUpgradePathRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	descriptor := meta.(*engineDescriptor)
+
+	currentVersion := d.Get(currentEngineVersionKey).(string)
+	targetVersion := d.Get(targetEngineVersionKey).(string)
+	path, err := descriptor.UpgradePath(ctx, currentVersion, targetVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", currentVersion, targetVersion))
+
+	tflog.Debug(ctx, "Setting DB engine upgrade path", map[string]any{
+		"upgrade_path": path,
+	})
+	if err := d.Set(upgradePathKey, path); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package csbmajorengineversion_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+)
+
+var _ = Describe("Upgrade path data source", func() {
+	DescribeTable("Upgrade paths can be resolved", func(engine, currentVersion, targetVersion string, hops int, expectedErrorMessage string) {
+		data, d := readDataSource(csbmajorengineversion.DataSourceUpgradePath(), offlineProvider(engine, nil), map[string]any{
+			"current_engine_version": currentVersion,
+			"target_engine_version":  targetVersion,
+		})
+		switch expectedErrorMessage {
+		case "":
+			Expect(d.HasError()).To(BeFalse())
+			Expect(data.Get("upgrade_path")).To(HaveLen(hops))
+		default:
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(ContainSubstring(expectedErrorMessage))
+		}
+	},
+		Entry("same version", "postgres", "15.7", "15.7", 0, ""),
+		Entry("minor upgrade", "mysql", "8.0.35", "8.0.36", 1, ""),
+		Entry("downgrade", "postgres", "15.7", "14.12", 0, "no upgrade path from postgres version 15.7 to 14.12"),
+		Entry("unknown version", "postgres", "invalid_engine_version", "15.7", 0, "version invalid_engine_version cannot be upgraded"),
+	)
+})
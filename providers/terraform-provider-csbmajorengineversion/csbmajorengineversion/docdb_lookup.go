@@ -0,0 +1,106 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/docdb"
+)
+
+// docDBLookup looks up the versions of the DocumentDB engine
+type docDBLookup struct {
+	client *docdb.Client
+}
+
+var _ VersionLookup = &docDBLookup{}
+
+func newDocDBLookup(cfg aws.Config) *docDBLookup {
+	return &docDBLookup{client: docdb.NewFromConfig(cfg)}
+}
+
+func (d *docDBLookup) EngineVersions(ctx context.Context, engineVersion string) ([]EngineVersion, error) {
+	params := &docdb.DescribeDBEngineVersionsInput{Engine: aws.String(docDBEngine)}
+	if engineVersion != "" {
+		params.EngineVersion = aws.String(engineVersion)
+	}
+
+	var versions []EngineVersion
+	paginator := docdb.NewDescribeDBEngineVersionsPaginator(d.client, params)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range output.DBEngineVersions {
+			var upgradeTargets []string
+			for _, target := range version.ValidUpgradeTarget {
+				upgradeTargets = append(upgradeTargets, aws.ToString(target.EngineVersion))
+			}
+
+			// DocumentDB versions such as "5.0.0" have the major version "5.0", which the API does not return
+			versions = append(versions, EngineVersion{
+				Engine:               aws.ToString(version.Engine),
+				Version:              aws.ToString(version.EngineVersion),
+				MajorVersion:         majorVersionOf(aws.ToString(version.EngineVersion), 2),
+				Status:               availableStatus,
+				ParameterGroupFamily: aws.ToString(version.DBParameterGroupFamily),
+				UpgradeTargets:       upgradeTargets,
+			})
+		}
+	}
+	return versions, nil
+}
+
+func (d *docDBLookup) DefaultVersion(ctx context.Context) (string, error) {
+	output, err := d.client.DescribeDBEngineVersions(ctx, &docdb.DescribeDBEngineVersionsInput{
+		Engine:      aws.String(docDBEngine),
+		DefaultOnly: aws.Bool(true),
+	})
+	if err != nil || len(output.DBEngineVersions) == 0 {
+		return "", err
+	}
+	return aws.ToString(output.DBEngineVersions[0].EngineVersion), nil
+}
+
+// InstanceClasses returns the DB instance classes that can be ordered in a VPC
+func (d *docDBLookup) InstanceClasses(ctx context.Context) (map[string][]string, error) {
+	paginator := docdb.NewDescribeOrderableDBInstanceOptionsPaginator(d.client, &docdb.DescribeOrderableDBInstanceOptionsInput{
+		Engine:     aws.String(docDBEngine),
+		Vpc:        aws.Bool(true),
+		MaxRecords: aws.Int32(100),
+	})
+
+	found := make(map[string]map[string]struct{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, option := range output.OrderableDBInstanceOptions {
+			addInstanceClass(found, aws.ToString(option.EngineVersion), aws.ToString(option.DBInstanceClass))
+		}
+	}
+	return sortedInstanceClasses(found), nil
+}
+
+// ParameterGroupFamily looks up a cluster parameter group, as DocumentDB parameters are set at the cluster level
+func (d *docDBLookup) ParameterGroupFamily(ctx context.Context, parameterGroupName string) (string, error) {
+	output, err := d.client.DescribeDBClusterParameterGroups(ctx, &docdb.DescribeDBClusterParameterGroupsInput{
+		DBClusterParameterGroupName: aws.String(parameterGroupName),
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case len(output.DBClusterParameterGroups) == 0:
+		return "", fmt.Errorf("parameter group %s does not exist", parameterGroupName)
+	default:
+		return aws.ToString(output.DBClusterParameterGroups[0].DBParameterGroupFamily), nil
+	}
+}
+
+// EndOfStandardSupport returns the zero time, as DocumentDB does not publish support dates through its API
+func (d *docDBLookup) EndOfStandardSupport(context.Context, string) (time.Time, error) {
+	return time.Time{}, nil
+}
@@ -0,0 +1,93 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+)
+
+// elastiCacheLookup looks up the versions of the Redis, Valkey and Memcached engines
+type elastiCacheLookup struct {
+	engine string
+	client *elasticache.Client
+}
+
+var _ VersionLookup = &elastiCacheLookup{}
+
+func newElastiCacheLookup(engine string, cfg aws.Config) *elastiCacheLookup {
+	return &elastiCacheLookup{engine: engine, client: elasticache.NewFromConfig(cfg)}
+}
+
+func (e *elastiCacheLookup) EngineVersions(ctx context.Context, engineVersion string) ([]EngineVersion, error) {
+	params := &elasticache.DescribeCacheEngineVersionsInput{Engine: aws.String(e.engine)}
+	if engineVersion != "" {
+		params.EngineVersion = aws.String(engineVersion)
+	}
+
+	var versions []EngineVersion
+	paginator := elasticache.NewDescribeCacheEngineVersionsPaginator(e.client, params)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range output.CacheEngineVersions {
+			// ElastiCache only lists versions that can be used, and has no upgrade targets
+			versions = append(versions, EngineVersion{
+				Engine:               aws.ToString(version.Engine),
+				Version:              aws.ToString(version.EngineVersion),
+				MajorVersion:         e.majorVersion(aws.ToString(version.EngineVersion)),
+				Status:               availableStatus,
+				ParameterGroupFamily: aws.ToString(version.CacheParameterGroupFamily),
+			})
+		}
+	}
+	return versions, nil
+}
+
+// majorVersion follows the ElastiCache convention that Redis and Valkey versions such as "7.1" have the major version "7",
+// while Memcached versions such as "1.6.22" have the major version "1.6"
+func (e *elastiCacheLookup) majorVersion(version string) string {
+	if e.engine == "memcached" {
+		return majorVersionOf(version, 2)
+	}
+	return majorVersionOf(version, 1)
+}
+
+func (e *elastiCacheLookup) DefaultVersion(ctx context.Context) (string, error) {
+	output, err := e.client.DescribeCacheEngineVersions(ctx, &elasticache.DescribeCacheEngineVersionsInput{
+		Engine:      aws.String(e.engine),
+		DefaultOnly: aws.Bool(true),
+	})
+	if err != nil || len(output.CacheEngineVersions) == 0 {
+		return "", err
+	}
+	return aws.ToString(output.CacheEngineVersions[0].EngineVersion), nil
+}
+
+// InstanceClasses is empty, as ElastiCache node types do not depend on the engine version
+func (e *elastiCacheLookup) InstanceClasses(context.Context) (map[string][]string, error) {
+	return nil, nil
+}
+
+func (e *elastiCacheLookup) ParameterGroupFamily(ctx context.Context, parameterGroupName string) (string, error) {
+	output, err := e.client.DescribeCacheParameterGroups(ctx, &elasticache.DescribeCacheParameterGroupsInput{
+		CacheParameterGroupName: aws.String(parameterGroupName),
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case len(output.CacheParameterGroups) == 0:
+		return "", fmt.Errorf("parameter group %s does not exist", parameterGroupName)
+	default:
+		return aws.ToString(output.CacheParameterGroups[0].CacheParameterGroupFamily), nil
+	}
+}
+
+// EndOfStandardSupport returns the zero time, as ElastiCache does not publish support dates through its API
+func (e *elastiCacheLookup) EndOfStandardSupport(context.Context, string) (time.Time, error) {
+	return time.Time{}, nil
+}
@@ -3,56 +3,215 @@ package csbmajorengineversion
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type engineDescriptor struct {
-	engine          string
-	accessKeyID     string
-	secretAccessKey string
-	region          string
+	engine   string
+	region   string
+	endpoint string
+	lookup   VersionLookup
+	cacheTTL time.Duration
+	policy   versionPolicy
 }
 
-func NewEngineDescriptor(engine, accessKeyID, secretAccessKey, region string) *engineDescriptor {
-	return &engineDescriptor{engine: engine, accessKeyID: accessKeyID, secretAccessKey: secretAccessKey, region: region}
+// NewEngineDescriptor builds the AWS client once, so that it is shared by every data source read
+func NewEngineDescriptor(ctx context.Context, engine string, settings AWSSettings, cacheTTL time.Duration) (*engineDescriptor, error) {
+	cfg, err := loadAWSConfig(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engineDescriptor{
+		engine:   engine,
+		region:   settings.Region,
+		endpoint: settings.CustomEndpointURL,
+		lookup:   newVersionLookup(engine, cfg, settings.CustomEndpointURL),
+		cacheTTL: cacheTTL,
+	}, nil
 }
 
-func (e *engineDescriptor) Describe(ctx context.Context, engineVersion string) (string, error) {
-	credentialsCache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(e.accessKeyID, e.secretAccessKey, ""))
+// NewRDSEngineDescriptor looks up the engine versions with the given RDS client, which makes it possible to inject a fake client
+func NewRDSEngineDescriptor(engine, region string, client RDSClient, cacheTTL time.Duration) *engineDescriptor {
+	return &engineDescriptor{engine: engine, region: region, lookup: &rdsLookup{engine: engine, client: client}, cacheTTL: cacheTTL}
+}
 
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithCredentialsProvider(credentialsCache))
+// NewOfflineEngineDescriptor answers from the catalog. Nothing is cached, as the catalog is already in memory.
+func NewOfflineEngineDescriptor(engine string, catalog Catalog) (*engineDescriptor, error) {
+	lookup, err := newCatalogLookup(engine, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engineDescriptor{engine: engine, lookup: lookup}, nil
+}
+
+func (e *engineDescriptor) Describe(ctx context.Context, engineVersion string) (string, error) {
+	version, err := e.describeEngineVersion(ctx, engineVersion)
 	if err != nil {
 		return "", err
 	}
 
-	tflog.Debug(ctx, "Retrieving AWS DB engine versions", map[string]any{
-		"engine":         e.engine,
-		"engine_version": engineVersion,
+	return version.MajorVersion, nil
+}
+
+// CheckParameterGroup returns an error when the named parameter group does not exist or has a different family
+func (e *engineDescriptor) CheckParameterGroup(ctx context.Context, parameterGroupName, family string) error {
+	tflog.Debug(ctx, "Retrieving AWS parameter group", map[string]any{
+		"parameter_group_name": parameterGroupName,
+	})
+	actual, err := e.lookup.ParameterGroupFamily(ctx, parameterGroupName)
+	if err != nil {
+		return err
+	}
+
+	if actual != family {
+		return fmt.Errorf("parameter group %s has family %s, but engine %s requires family %s", parameterGroupName, actual, e.engine, family)
+	}
+	return nil
+}
+
+// EndOfStandardSupport returns the date when standard support ends for the major version.
+// The zero time is returned when no date is published, which is the case for commercial engines.
+func (e *engineDescriptor) EndOfStandardSupport(ctx context.Context, majorVersion string) (time.Time, error) {
+	key := supportCacheKey{engine: e.engine, majorVersion: majorVersion, region: e.region, endpoint: e.endpoint}
+	if end, ok := supportCache.get(key); ok {
+		return end, nil
+	}
+
+	tflog.Debug(ctx, "Retrieving AWS major engine version lifecycle", map[string]any{
+		"engine":               e.engine,
+		"major_engine_version": majorVersion,
 	})
-	awsClient := rds.NewFromConfig(cfg)
-	params := &rds.DescribeDBEngineVersionsInput{
-		Engine:        aws.String(e.engine),
-		EngineVersion: aws.String(engineVersion),
-		IncludeAll:    aws.Bool(true), // If false, Postgres version 14.2 does not return any output because it is no longer listed in the AWS console
+	end, err := e.lookup.EndOfStandardSupport(ctx, majorVersion)
+	if err != nil {
+		return time.Time{}, err
 	}
-	optFns := func(options *rds.Options) { options.Region = e.region }
-	output, err := awsClient.DescribeDBEngineVersions(ctx, params, optFns)
+
+	supportCache.put(key, end, e.cacheTTL)
+	return end, nil
+}
+
+// describeEngineVersion returns the engine version that best matches the version, see bestMatch
+func (e *engineDescriptor) describeEngineVersion(ctx context.Context, engineVersion string) (EngineVersion, error) {
+	versions, err := e.describeEngineVersions(ctx, engineVersion)
 	if err != nil {
-		return "", err
+		return EngineVersion{}, err
 	}
 
-	if len(output.DBEngineVersions) == 0 {
-		return "", fmt.Errorf(
+	version, ok := bestMatch(engineVersion, versions)
+	if !ok {
+		return EngineVersion{}, fmt.Errorf(
 			"invalid parameter combination. API does not return any db engine version - engine %s - engine version %s",
 			e.engine,
 			engineVersion,
 		)
 	}
 
-	return aws.ToString(output.DBEngineVersions[0].MajorEngineVersion), nil
+	return version, nil
+}
+
+// UpgradePath returns the shortest list of versions that an instance must be upgraded to, one after the other,
+// in order to get from the current version to the target version. The current version is not included.
+func (e *engineDescriptor) UpgradePath(ctx context.Context, currentVersion, targetVersion string) ([]string, error) {
+	versions, err := e.describeEngineVersions(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	upgradeTargets := make(map[string][]string, len(versions))
+	for _, version := range versions {
+		if len(version.UpgradeTargets) > 0 {
+			upgradeTargets[version.Version] = version.UpgradeTargets
+		}
+	}
+
+	if _, ok := upgradeTargets[currentVersion]; !ok && currentVersion != targetVersion {
+		return nil, fmt.Errorf("no upgrade path from %s version %s to %s: version %s cannot be upgraded", e.engine, currentVersion, targetVersion, currentVersion)
+	}
+
+	// Breadth first search, so that the path with the fewest upgrades is found
+	previous := map[string]string{currentVersion: ""}
+	queue := []string{currentVersion}
+	for len(queue) > 0 {
+		version := queue[0]
+		queue = queue[1:]
+
+		if version == targetVersion {
+			var path []string
+			for ; version != currentVersion; version = previous[version] {
+				path = append([]string{version}, path...)
+			}
+			return path, nil
+		}
+
+		for _, target := range upgradeTargets[version] {
+			if _, seen := previous[target]; !seen {
+				previous[target] = version
+				queue = append(queue, target)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no upgrade path from %s version %s to %s", e.engine, currentVersion, targetVersion)
+}
+
+// describeEngineVersions returns the engine versions matching the version, from the cache when possible.
+// An empty version matches every version of the engine.
+func (e *engineDescriptor) describeEngineVersions(ctx context.Context, engineVersion string) ([]EngineVersion, error) {
+	key := versionCacheKey{engine: e.engine, engineVersion: engineVersion, region: e.region, endpoint: e.endpoint}
+	if versions, ok := engineVersionCache.get(key); ok {
+		tflog.Debug(ctx, "Using cached AWS engine versions", map[string]any{
+			"engine":         e.engine,
+			"engine_version": engineVersion,
+		})
+		return versions, nil
+	}
+
+	tflog.Debug(ctx, "Retrieving AWS engine versions", map[string]any{
+		"engine":         e.engine,
+		"engine_version": engineVersion,
+	})
+	versions, err := e.lookup.EngineVersions(ctx, engineVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	engineVersionCache.put(key, versions, e.cacheTTL)
+	return versions, nil
+}
+
+// defaultEngineVersion returns the version that is used when no version is specified
+func (e *engineDescriptor) defaultEngineVersion(ctx context.Context) (string, error) {
+	key := engineCacheKey{engine: e.engine, region: e.region, endpoint: e.endpoint}
+	if version, ok := defaultVersionCache.get(key); ok {
+		return version, nil
+	}
+
+	version, err := e.lookup.DefaultVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defaultVersionCache.put(key, version, e.cacheTTL)
+	return version, nil
+}
+
+// instanceClasses returns the instance classes that can be ordered for every version of the engine
+func (e *engineDescriptor) instanceClasses(ctx context.Context) (map[string][]string, error) {
+	key := engineCacheKey{engine: e.engine, region: e.region, endpoint: e.endpoint}
+	if classes, ok := instanceClassCache.get(key); ok {
+		return classes, nil
+	}
+
+	tflog.Debug(ctx, "Retrieving AWS orderable instance options", map[string]any{"engine": e.engine})
+	classes, err := e.lookup.InstanceClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceClassCache.put(key, classes, e.cacheTTL)
+	return classes, nil
 }
@@ -1,11 +1,38 @@
 package csbmajorengineversion
 
 const (
-	engineKey             = "engine"
-	awsAccessKeyIDKey     = "access_key_id"
-	awsSecretAccessKeyKey = "secret_access_key"
-	awsRegionKey          = "region"
-	engineVersionKey      = "engine_version"
-	majorVersionKey       = "major_version"
-	DataResourceNameKey   = "csbmajorengineversion"
+	engineKey                = "engine"
+	awsAccessKeyIDKey        = "access_key_id"
+	awsSecretAccessKeyKey    = "secret_access_key"
+	awsRegionKey             = "region"
+	awsSessionTokenKey       = "session_token"
+	assumeRoleARNKey         = "assume_role_arn"
+	externalIDKey            = "external_id"
+	roleSessionNameKey       = "role_session_name"
+	useDefaultCredsKey       = "use_default_credentials"
+	customEndpointURLKey     = "custom_endpoint_url"
+	cacheTTLKey              = "cache_ttl"
+	failOnDeprecatedKey      = "fail_on_deprecated_version"
+	supportWarningDaysKey    = "end_of_support_warning_days"
+	offlineKey               = "offline"
+	catalogPathKey           = "catalog_path"
+	engineVersionKey         = "engine_version"
+	majorVersionKey          = "major_version"
+	parameterGroupNameKey    = "parameter_group_name"
+	endOfStandardSupportKey  = "end_of_standard_support"
+	resolvedEngineVersionKey = "resolved_engine_version"
+	DataResourceNameKey      = "csbmajorengineversion"
+
+	VersionsDataResourceNameKey = "csbmajorengineversion_versions"
+	versionsKey                 = "versions"
+	statusKey                   = "status"
+	isDefaultKey                = "is_default"
+	validUpgradeTargetsKey      = "valid_upgrade_targets"
+	parameterGroupFamilyKey     = "parameter_group_family"
+	instanceClassesKey          = "instance_classes"
+
+	UpgradePathDataResourceNameKey = "csbmajorengineversion_upgrade_path"
+	currentEngineVersionKey        = "current_engine_version"
+	targetEngineVersionKey         = "target_engine_version"
+	upgradePathKey                 = "upgrade_path"
 )
@@ -1,21 +1,27 @@
-// Package csbmajorengineversion is a Terraform provider designed to get the RDS major version given an engine and a reference version.
+// Package csbmajorengineversion is a Terraform provider designed to get the RDS, ElastiCache or DocumentDB major version given an engine and a reference version.
 package csbmajorengineversion
 
 import (
 	"context"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const defaultCacheTTL = 15 * time.Minute
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema:               ProviderSchema(),
 		ConfigureContextFunc: ProviderConfigureContext,
 		DataSourcesMap: map[string]*schema.Resource{
-			DataResourceNameKey: DataSourceMajorEngineVersion(),
+			DataResourceNameKey:            DataSourceMajorEngineVersion(),
+			VersionsDataResourceNameKey:    DataSourceEngineVersions(),
+			UpgradePathDataResourceNameKey: DataSourceUpgradePath(),
 		},
 	}
 }
@@ -29,12 +35,12 @@ func ProviderSchema() map[string]*schema.Schema {
 		},
 		awsAccessKeyIDKey: {
 			Type:         schema.TypeString,
-			Required:     true,
+			Optional:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 		awsSecretAccessKeyKey: {
 			Type:         schema.TypeString,
-			Required:     true,
+			Optional:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 		awsRegionKey: {
@@ -42,15 +48,133 @@ func ProviderSchema() map[string]*schema.Schema {
 			Required:     true,
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
+		awsSessionTokenKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Session token for temporary credentials",
+		},
+		useDefaultCredsKey: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Use the default AWS credential chain instead of static credentials",
+		},
+		assumeRoleARNKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "ARN of a role to assume using the static or default credentials",
+		},
+		externalIDKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "External ID required by the trust policy of the role",
+		},
+		roleSessionNameKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Session name used when assuming the role",
+		},
+		customEndpointURLKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			Description:  "Endpoint of the RDS API, for FIPS endpoints or local stand-ins such as LocalStack. STS, ElastiCache and DocumentDB keep the endpoints of the region",
+		},
+		cacheTTLKey: {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          defaultCacheTTL.String(),
+			ValidateDiagFunc: validateDuration,
+			Description:      "How long DB engine versions are cached for. Zero disables caching",
+		},
+		failOnDeprecatedKey: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Fail instead of warning when a version is deprecated or past the end of RDS standard support",
+		},
+		supportWarningDaysKey: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultSupportWarningDays,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Warn when a version reaches the end of RDS standard support within this number of days",
+		},
+		offlineKey: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Answer from the engine version catalog embedded in the provider instead of the AWS APIs",
+		},
+		catalogPathKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Path to an engine version catalog that replaces the embedded catalog. Implies offline",
+		},
 	}
 }
 
 func ProviderConfigureContext(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 	tflog.Debug(ctx, "Configuring Terraform csbmajorengineversion Provider")
 	engine := d.Get(engineKey).(string)
-	secret := d.Get(awsSecretAccessKeyKey).(string)
-	accessKey := d.Get(awsAccessKeyIDKey).(string)
-	region := d.Get(awsRegionKey).(string)
+	creds := Credentials{
+		UseDefaultChain: d.Get(useDefaultCredsKey).(bool),
+		AccessKeyID:     d.Get(awsAccessKeyIDKey).(string),
+		SecretAccessKey: d.Get(awsSecretAccessKeyKey).(string),
+		SessionToken:    d.Get(awsSessionTokenKey).(string),
+		AssumeRoleARN:   d.Get(assumeRoleARNKey).(string),
+		ExternalID:      d.Get(externalIDKey).(string),
+		RoleSessionName: d.Get(roleSessionNameKey).(string),
+	}
+
+	cacheTTL, err := time.ParseDuration(d.Get(cacheTTLKey).(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var descriptor *engineDescriptor
+	switch catalogPath := d.Get(catalogPathKey).(string); {
+	case d.Get(offlineKey).(bool) || catalogPath != "":
+		catalog, err := LoadCatalog(catalogPath)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if descriptor, err = NewOfflineEngineDescriptor(engine, catalog); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	case creds.UseDefaultChain && (creds.AccessKeyID != "" || creds.SecretAccessKey != "" || creds.SessionToken != ""):
+		return nil, diag.Errorf("%q cannot be combined with static credentials", useDefaultCredsKey)
+	case !creds.UseDefaultChain && (creds.AccessKeyID == "" || creds.SecretAccessKey == ""):
+		return nil, diag.Errorf("%q and %q are required unless %q is set or the provider is offline", awsAccessKeyIDKey, awsSecretAccessKeyKey, useDefaultCredsKey)
+	default:
+		settings := AWSSettings{
+			Region:            d.Get(awsRegionKey).(string),
+			CustomEndpointURL: d.Get(customEndpointURLKey).(string),
+			Credentials:       creds,
+		}
+		if descriptor, err = NewEngineDescriptor(ctx, engine, settings, cacheTTL); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
 
-	return NewEngineDescriptor(engine, accessKey, secret, region), nil
+	descriptor.policy = versionPolicy{
+		failOnDeprecated: d.Get(failOnDeprecatedKey).(bool),
+		warningPeriod:    time.Duration(d.Get(supportWarningDaysKey).(int)) * 24 * time.Hour,
+	}
+	return descriptor, nil
+}
+
+func validateDuration(v any, p cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(v.(string))
+	switch {
+	case err != nil:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: err.Error(), AttributePath: p}}
+	case duration < 0:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: "duration must not be negative", AttributePath: p}}
+	default:
+		return nil
+	}
 }
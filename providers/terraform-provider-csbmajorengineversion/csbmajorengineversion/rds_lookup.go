@@ -0,0 +1,165 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbmajorengineversionfakes/header.txt . RDSClient
+type RDSClient interface {
+	rds.DescribeDBEngineVersionsAPIClient
+	rds.DescribeOrderableDBInstanceOptionsAPIClient
+	DescribeDBParameterGroups(context.Context, *rds.DescribeDBParameterGroupsInput, ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error)
+	DescribeDBMajorEngineVersions(context.Context, *rds.DescribeDBMajorEngineVersionsInput, ...func(*rds.Options)) (*rds.DescribeDBMajorEngineVersionsOutput, error)
+}
+
+var _ RDSClient = &rds.Client{}
+
+type rdsLookup struct {
+	engine string
+	client RDSClient
+}
+
+var _ VersionLookup = &rdsLookup{}
+
+func newRDSLookup(engine string, cfg aws.Config, endpoint string) *rdsLookup {
+	return &rdsLookup{engine: engine, client: rds.NewFromConfig(cfg, func(o *rds.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})}
+}
+
+func (r *rdsLookup) EngineVersions(ctx context.Context, engineVersion string) ([]EngineVersion, error) {
+	params := &rds.DescribeDBEngineVersionsInput{
+		Engine:     aws.String(r.engine),
+		IncludeAll: aws.Bool(true), // If false, Postgres version 14.2 does not return any output because it is no longer listed in the AWS console
+	}
+	if engineVersion != "" {
+		params.EngineVersion = aws.String(engineVersion)
+	}
+
+	var versions []EngineVersion
+	paginator := rds.NewDescribeDBEngineVersionsPaginator(r.client, params)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range output.DBEngineVersions {
+			versions = append(versions, r.engineVersion(version))
+		}
+	}
+	return versions, nil
+}
+
+func (r *rdsLookup) engineVersion(version types.DBEngineVersion) EngineVersion {
+	var upgradeTargets []string
+	for _, target := range version.ValidUpgradeTarget {
+		if target.Engine == nil || aws.ToString(target.Engine) == r.engine {
+			upgradeTargets = append(upgradeTargets, aws.ToString(target.EngineVersion))
+		}
+	}
+
+	return EngineVersion{
+		Engine:               aws.ToString(version.Engine),
+		Version:              aws.ToString(version.EngineVersion),
+		MajorVersion:         aws.ToString(version.MajorEngineVersion),
+		Status:               aws.ToString(version.Status),
+		ParameterGroupFamily: aws.ToString(version.DBParameterGroupFamily),
+		UpgradeTargets:       upgradeTargets,
+	}
+}
+
+func (r *rdsLookup) DefaultVersion(ctx context.Context) (string, error) {
+	output, err := r.client.DescribeDBEngineVersions(ctx, &rds.DescribeDBEngineVersionsInput{
+		Engine:      aws.String(r.engine),
+		DefaultOnly: aws.Bool(true),
+	})
+	if err != nil || len(output.DBEngineVersions) == 0 {
+		return "", err
+	}
+	return aws.ToString(output.DBEngineVersions[0].EngineVersion), nil
+}
+
+// InstanceClasses returns the DB instance classes that can be ordered in a VPC
+func (r *rdsLookup) InstanceClasses(ctx context.Context) (map[string][]string, error) {
+	paginator := rds.NewDescribeOrderableDBInstanceOptionsPaginator(r.client, &rds.DescribeOrderableDBInstanceOptionsInput{
+		Engine:     aws.String(r.engine),
+		Vpc:        aws.Bool(true),
+		MaxRecords: aws.Int32(1000),
+	})
+
+	found := make(map[string]map[string]struct{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, option := range output.OrderableDBInstanceOptions {
+			addInstanceClass(found, aws.ToString(option.EngineVersion), aws.ToString(option.DBInstanceClass))
+		}
+	}
+	return sortedInstanceClasses(found), nil
+}
+
+func (r *rdsLookup) ParameterGroupFamily(ctx context.Context, parameterGroupName string) (string, error) {
+	output, err := r.client.DescribeDBParameterGroups(ctx, &rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(parameterGroupName),
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case len(output.DBParameterGroups) == 0:
+		return "", fmt.Errorf("parameter group %s does not exist", parameterGroupName)
+	default:
+		return aws.ToString(output.DBParameterGroups[0].DBParameterGroupFamily), nil
+	}
+}
+
+// EndOfStandardSupport returns the zero time for commercial engines, as RDS only publishes dates for open source engines
+func (r *rdsLookup) EndOfStandardSupport(ctx context.Context, majorVersion string) (time.Time, error) {
+	output, err := r.client.DescribeDBMajorEngineVersions(ctx, &rds.DescribeDBMajorEngineVersionsInput{
+		Engine:             aws.String(r.engine),
+		MajorEngineVersion: aws.String(majorVersion),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var end time.Time
+	for _, version := range output.DBMajorEngineVersions {
+		for _, lifecycle := range version.SupportedEngineLifecycles {
+			if lifecycle.LifecycleSupportName == types.LifecycleSupportNameOpenSourceRdsStandardSupport {
+				end = aws.ToTime(lifecycle.LifecycleSupportEndDate)
+			}
+		}
+	}
+	return end, nil
+}
+
+func addInstanceClass(found map[string]map[string]struct{}, version, instanceClass string) {
+	if found[version] == nil {
+		found[version] = make(map[string]struct{})
+	}
+	found[version][instanceClass] = struct{}{}
+}
+
+func sortedInstanceClasses(found map[string]map[string]struct{}) map[string][]string {
+	classes := make(map[string][]string, len(found))
+	for version, set := range found {
+		for class := range set {
+			classes[version] = append(classes[version], class)
+		}
+		slices.Sort(classes[version])
+	}
+	return classes
+}
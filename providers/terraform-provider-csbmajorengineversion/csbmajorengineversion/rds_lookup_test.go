@@ -0,0 +1,327 @@
+package csbmajorengineversion_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-majorengineversion/csbmajorengineversion/csbmajorengineversionfakes"
+)
+
+var _ = Describe("RDS engine versions", func() {
+	var client *csbmajorengineversionfakes.FakeRDSClient
+
+	BeforeEach(func() {
+		client = &csbmajorengineversionfakes.FakeRDSClient{}
+		client.DescribeDBMajorEngineVersionsReturns(&rds.DescribeDBMajorEngineVersionsOutput{}, nil)
+	})
+
+	read := func(resource *schema.Resource, meta any, raw map[string]any) (*schema.ResourceData, diag.Diagnostics) {
+		data := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, raw)
+		return data, resource.ReadContext(context.TODO(), data, meta)
+	}
+
+	dbEngineVersion := func(version, majorVersion, status string, targets ...string) types.DBEngineVersion {
+		v := types.DBEngineVersion{
+			Engine:                 aws.String("postgres"),
+			EngineVersion:          aws.String(version),
+			MajorEngineVersion:     aws.String(majorVersion),
+			Status:                 aws.String(status),
+			DBParameterGroupFamily: aws.String("postgres" + majorVersion),
+		}
+		for _, target := range targets {
+			v.ValidUpgradeTarget = append(v.ValidUpgradeTarget, types.UpgradeTarget{Engine: aws.String("postgres"), EngineVersion: aws.String(target)})
+		}
+		return v
+	}
+
+	Describe("major engine version", func() {
+		var descriptor any
+
+		BeforeEach(func() {
+			descriptor = csbmajorengineversion.NewRDSEngineDescriptor("postgres", "us-west-2", client, 0)
+		})
+
+		It("describes the requested engine version", func() {
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.7", "15", "available")},
+			}, nil)
+
+			data, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7"})
+			Expect(d).To(BeEmpty())
+			Expect(data.Get("major_version")).To(Equal("15"))
+			Expect(data.Get("parameter_group_family")).To(Equal("postgres15"))
+			Expect(data.Get("status")).To(Equal("available"))
+
+			Expect(client.DescribeDBEngineVersionsCallCount()).To(Equal(2), "the version and the default version")
+			_, input, _ := client.DescribeDBEngineVersionsArgsForCall(0)
+			Expect(aws.ToString(input.Engine)).To(Equal("postgres"))
+			Expect(aws.ToString(input.EngineVersion)).To(Equal("15.7"))
+			Expect(aws.ToBool(input.IncludeAll)).To(BeTrue())
+		})
+
+		DescribeTable("picks the best match of several returned versions, whatever their order", func(engineVersion, resolvedVersion string, versions ...types.DBEngineVersion) {
+			client.DescribeDBEngineVersionsStub = func(_ context.Context, input *rds.DescribeDBEngineVersionsInput, _ ...func(*rds.Options)) (*rds.DescribeDBEngineVersionsOutput, error) {
+				if aws.ToBool(input.DefaultOnly) {
+					return &rds.DescribeDBEngineVersionsOutput{DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("14.12", "14", "available")}}, nil
+				}
+				return &rds.DescribeDBEngineVersionsOutput{DBEngineVersions: versions}, nil
+			}
+
+			data, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": engineVersion})
+			Expect(d.HasError()).To(BeFalse())
+			Expect(data.Get("resolved_engine_version")).To(Equal(resolvedVersion))
+			Expect(data.Get("is_default")).To(Equal(resolvedVersion == "14.12"))
+		},
+			Entry("exact version", "14.9", "14.9",
+				dbEngineVersion("14.12", "14", "available"),
+				dbEngineVersion("14.9", "14", "deprecated"),
+				dbEngineVersion("14.10", "14", "available"),
+			),
+			Entry("highest available minor version", "14", "14.12",
+				dbEngineVersion("14.10", "14", "available"),
+				dbEngineVersion("14.12", "14", "available"),
+				dbEngineVersion("14.9", "14", "available"),
+				dbEngineVersion("14.13", "14", "deprecated"),
+			),
+			Entry("highest deprecated version when none is available", "14", "14.10",
+				dbEngineVersion("14.9", "14", "deprecated"),
+				dbEngineVersion("14.10", "14", "deprecated"),
+			),
+			Entry("versions of the family before other versions", "14.1", "14.1.2",
+				dbEngineVersion("14.10", "14", "available"),
+				dbEngineVersion("14.1.2", "14", "deprecated"),
+			),
+			Entry("numeric comparison of non numeric versions", "8.0.mysql_aurora.3", "8.0.mysql_aurora.3.10.0",
+				dbEngineVersion("8.0.mysql_aurora.3.9.1", "8.0", "available"),
+				dbEngineVersion("8.0.mysql_aurora.3.10.0", "8.0", "available"),
+			),
+		)
+
+		It("reports an empty response", func() {
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{}, nil)
+
+			_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "99"})
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(Equal("invalid parameter combination. API does not return any db engine version - engine postgres - engine version 99"))
+		})
+
+		It("reports API errors", func() {
+			client.DescribeDBEngineVersionsReturns(nil, errors.New("boom"))
+
+			_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7"})
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(Equal("boom"))
+		})
+
+		It("reads the end of standard support", func() {
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("11.22", "11", "available")},
+			}, nil)
+			client.DescribeDBMajorEngineVersionsReturns(&rds.DescribeDBMajorEngineVersionsOutput{
+				DBMajorEngineVersions: []types.DBMajorEngineVersion{{
+					SupportedEngineLifecycles: []types.SupportedEngineLifecycle{
+						{
+							LifecycleSupportName:    types.LifecycleSupportNameOpenSourceRdsExtendedSupport,
+							LifecycleSupportEndDate: aws.Time(time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC)),
+						},
+						{
+							LifecycleSupportName:    types.LifecycleSupportNameOpenSourceRdsStandardSupport,
+							LifecycleSupportEndDate: aws.Time(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+						},
+					},
+				}},
+			}, nil)
+
+			data, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "11.22"})
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal("postgres version 11.22 reached the end of RDS standard support on 2024-02-29"))
+			Expect(data.Get("end_of_standard_support")).To(Equal("2024-02-29"))
+
+			_, input, _ := client.DescribeDBMajorEngineVersionsArgsForCall(0)
+			Expect(aws.ToString(input.MajorEngineVersion)).To(Equal("11"))
+		})
+
+		It("warns when the end of standard support cannot be read", func() {
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.7", "15", "available")},
+			}, nil)
+			client.DescribeDBMajorEngineVersionsReturns(nil, errors.New("access denied"))
+
+			data, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7"})
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal("could not check the end of RDS standard support"))
+			Expect(data.Get("major_version")).To(Equal("15"))
+		})
+
+		Context("parameter groups", func() {
+			BeforeEach(func() {
+				client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+					DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.7", "15", "available")},
+				}, nil)
+			})
+
+			It("accepts a parameter group of the right family", func() {
+				client.DescribeDBParameterGroupsReturns(&rds.DescribeDBParameterGroupsOutput{
+					DBParameterGroups: []types.DBParameterGroup{{DBParameterGroupFamily: aws.String("postgres15")}},
+				}, nil)
+
+				_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7", "parameter_group_name": "csb-pg"})
+				Expect(d).To(BeEmpty())
+				_, input, _ := client.DescribeDBParameterGroupsArgsForCall(0)
+				Expect(aws.ToString(input.DBParameterGroupName)).To(Equal("csb-pg"))
+			})
+
+			It("rejects a parameter group of another family", func() {
+				client.DescribeDBParameterGroupsReturns(&rds.DescribeDBParameterGroupsOutput{
+					DBParameterGroups: []types.DBParameterGroup{{DBParameterGroupFamily: aws.String("postgres14")}},
+				}, nil)
+
+				_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7", "parameter_group_name": "csb-pg"})
+				Expect(d.HasError()).To(BeTrue())
+				Expect(d[0].Summary).To(Equal("parameter group csb-pg has family postgres14, but engine postgres requires family postgres15"))
+			})
+
+			It("rejects a parameter group that does not exist", func() {
+				client.DescribeDBParameterGroupsReturns(&rds.DescribeDBParameterGroupsOutput{}, nil)
+
+				_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7", "parameter_group_name": "csb-pg"})
+				Expect(d.HasError()).To(BeTrue())
+				Expect(d[0].Summary).To(Equal("parameter group csb-pg does not exist"))
+			})
+		})
+	})
+
+	Describe("engine versions", func() {
+		var descriptor any
+
+		BeforeEach(func() {
+			descriptor = csbmajorengineversion.NewRDSEngineDescriptor("postgres", "us-west-2", client, 0)
+			client.DescribeDBEngineVersionsStub = func(_ context.Context, input *rds.DescribeDBEngineVersionsInput, _ ...func(*rds.Options)) (*rds.DescribeDBEngineVersionsOutput, error) {
+				switch {
+				case aws.ToBool(input.DefaultOnly):
+					return &rds.DescribeDBEngineVersionsOutput{DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("16.3", "16", "available")}}, nil
+				case input.Marker == nil:
+					return &rds.DescribeDBEngineVersionsOutput{
+						DBEngineVersions: []types.DBEngineVersion{
+							dbEngineVersion("14.2", "14", "deprecated", "14.12"),
+							dbEngineVersion("14.12", "14", "available", "15.7", "16.3"),
+						},
+						Marker: aws.String("page-2"),
+					}, nil
+				default:
+					return &rds.DescribeDBEngineVersionsOutput{
+						DBEngineVersions: []types.DBEngineVersion{
+							dbEngineVersion("15.7", "15", "available", "16.3"),
+							dbEngineVersion("16.3", "16", "available"),
+						},
+					}, nil
+				}
+			}
+			client.DescribeOrderableDBInstanceOptionsReturns(&rds.DescribeOrderableDBInstanceOptionsOutput{
+				OrderableDBInstanceOptions: []types.OrderableDBInstanceOption{
+					{EngineVersion: aws.String("16.3"), DBInstanceClass: aws.String("db.t4g.micro")},
+					{EngineVersion: aws.String("16.3"), DBInstanceClass: aws.String("db.m6i.large")},
+					{EngineVersion: aws.String("16.3"), DBInstanceClass: aws.String("db.t4g.micro")},
+				},
+			}, nil)
+		})
+
+		It("lists the versions of every page", func() {
+			data, d := read(csbmajorengineversion.DataSourceEngineVersions(), descriptor, map[string]any{})
+			Expect(d).To(BeEmpty())
+			Expect(data.Get("versions.#")).To(Equal(4))
+			Expect(client.DescribeDBEngineVersionsCallCount()).To(Equal(3))
+		})
+
+		It("filters on the major version and adds the default version and instance classes", func() {
+			data, d := read(csbmajorengineversion.DataSourceEngineVersions(), descriptor, map[string]any{"major_version": "16"})
+			Expect(d).To(BeEmpty())
+			Expect(data.Get("versions")).To(ConsistOf(map[string]any{
+				"engine_version":         "16.3",
+				"major_version":          "16",
+				"status":                 "available",
+				"is_default":             true,
+				"parameter_group_family": "postgres16",
+				"valid_upgrade_targets":  []any{},
+				"instance_classes":       []any{"db.m6i.large", "db.t4g.micro"},
+			}))
+
+			_, input, _ := client.DescribeOrderableDBInstanceOptionsArgsForCall(0)
+			Expect(aws.ToString(input.Engine)).To(Equal("postgres"))
+			Expect(aws.ToBool(input.Vpc)).To(BeTrue())
+		})
+
+		It("reports API errors", func() {
+			client.DescribeOrderableDBInstanceOptionsReturns(nil, errors.New("throttled"))
+
+			_, d := read(csbmajorengineversion.DataSourceEngineVersions(), descriptor, map[string]any{})
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d[0].Summary).To(Equal("throttled"))
+		})
+
+		It("resolves the upgrade path", func() {
+			data, d := read(csbmajorengineversion.DataSourceUpgradePath(), descriptor, map[string]any{
+				"current_engine_version": "14.2",
+				"target_engine_version":  "16.3",
+			})
+			Expect(d).To(BeEmpty())
+			Expect(data.Get("upgrade_path")).To(Equal([]any{"14.12", "16.3"}))
+		})
+	})
+
+	Describe("region overrides", func() {
+		It("caches the versions of each region separately", func() {
+			otherClient := &csbmajorengineversionfakes.FakeRDSClient{}
+			for _, c := range []*csbmajorengineversionfakes.FakeRDSClient{client, otherClient} {
+				c.DescribeDBMajorEngineVersionsReturns(&rds.DescribeDBMajorEngineVersionsOutput{}, nil)
+			}
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.7", "15", "available")},
+			}, nil)
+			otherClient.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.8", "15", "deprecated")},
+			}, nil)
+
+			west := csbmajorengineversion.NewRDSEngineDescriptor("postgres", "test-west-1", client, time.Hour)
+			east := csbmajorengineversion.NewRDSEngineDescriptor("postgres", "test-east-1", otherClient, time.Hour)
+
+			for range 2 {
+				data, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), west, map[string]any{"engine_version": "15"})
+				Expect(d).To(BeEmpty())
+				Expect(data.Get("status")).To(Equal("available"))
+
+				data, _ = read(csbmajorengineversion.DataSourceMajorEngineVersion(), east, map[string]any{"engine_version": "15"})
+				Expect(data.Get("status")).To(Equal("deprecated"))
+			}
+
+			// One call for the version and one for the default version
+			Expect(client.DescribeDBEngineVersionsCallCount()).To(Equal(2))
+			Expect(otherClient.DescribeDBEngineVersionsCallCount()).To(Equal(2))
+		})
+
+		It("does not cache when caching is disabled", func() {
+			client.DescribeDBEngineVersionsReturns(&rds.DescribeDBEngineVersionsOutput{
+				DBEngineVersions: []types.DBEngineVersion{dbEngineVersion("15.7", "15", "available")},
+			}, nil)
+			descriptor := csbmajorengineversion.NewRDSEngineDescriptor("postgres", "test-central-1", client, 0)
+
+			for range 2 {
+				_, d := read(csbmajorengineversion.DataSourceMajorEngineVersion(), descriptor, map[string]any{"engine_version": "15.7"})
+				Expect(d).To(BeEmpty())
+			}
+			Expect(client.DescribeDBEngineVersionsCallCount()).To(Equal(4))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package csbmajorengineversion
+
+import (
+	"sync"
+	"time"
+)
+
+// The caches are shared by every provider configuration in the process, so that a plan that reads the
+// data sources several times, or for several service instances, only calls the RDS API once per lookup
+var (
+	engineVersionCache  = newTTLCache[versionCacheKey, []EngineVersion]()
+	defaultVersionCache = newTTLCache[engineCacheKey, string]()
+	instanceClassCache  = newTTLCache[engineCacheKey, map[string][]string]()
+	supportCache        = newTTLCache[supportCacheKey, time.Time]()
+)
+
+type versionCacheKey struct {
+	engine        string
+	engineVersion string
+	region        string
+	endpoint      string
+}
+
+type engineCacheKey struct {
+	engine   string
+	region   string
+	endpoint string
+}
+
+type supportCacheKey struct {
+	engine       string
+	majorVersion string
+	region       string
+	endpoint     string
+}
+
+type ttlCacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+type ttlCache[K comparable, V any] struct {
+	mutex   sync.Mutex
+	entries map[K]ttlCacheEntry[V]
+}
+
+func newTTLCache[K comparable, V any]() *ttlCache[K, V] {
+	return &ttlCache[K, V]{entries: make(map[K]ttlCacheEntry[V])}
+}
+
+func (c *ttlCache[K, V]) get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	switch {
+	case !ok:
+		var zero V
+		return zero, false
+	case time.Now().After(entry.expires):
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	default:
+		return entry.value, true
+	}
+}
+
+// put stores the value for the given time to live. A time to live of zero disables caching.
+func (c *ttlCache[K, V]) put(key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = ttlCacheEntry[V]{value: value, expires: time.Now().Add(ttl)}
+}
@@ -0,0 +1,57 @@
+package csbmajorengineversion
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// EngineVersion describes a version of an engine, whichever AWS service runs the engine
+type EngineVersion struct {
+	Engine               string
+	Version              string
+	MajorVersion         string
+	Status               string
+	ParameterGroupFamily string
+	UpgradeTargets       []string
+}
+
+// VersionLookup retrieves the versions of an engine from the AWS service that runs it
+type VersionLookup interface {
+	// EngineVersions returns the versions that match the engine version, or every version when it is empty
+	EngineVersions(ctx context.Context, engineVersion string) ([]EngineVersion, error)
+	// DefaultVersion returns the version that is used when no version is specified
+	DefaultVersion(ctx context.Context) (string, error)
+	// InstanceClasses returns the instance classes that can be ordered for each version. It can be empty.
+	InstanceClasses(ctx context.Context) (map[string][]string, error)
+	// ParameterGroupFamily returns the family of the named parameter group, or an error when it does not exist
+	ParameterGroupFamily(ctx context.Context, parameterGroupName string) (string, error)
+	// EndOfStandardSupport returns the date when standard support ends for the major version, or the zero time when none is published
+	EndOfStandardSupport(ctx context.Context, majorVersion string) (time.Time, error)
+}
+
+const (
+	availableStatus = "available"
+	docDBEngine     = "docdb"
+)
+
+// newVersionLookup selects the AWS service that runs the engine. The RDS endpoint, when not empty, replaces
+// the endpoint of the region for RDS engines.
+func newVersionLookup(engine string, cfg aws.Config, rdsEndpoint string) VersionLookup {
+	switch engine {
+	case "redis", "valkey", "memcached":
+		return newElastiCacheLookup(engine, cfg)
+	case docDBEngine:
+		return newDocDBLookup(cfg)
+	default:
+		return newRDSLookup(engine, cfg, rdsEndpoint)
+	}
+}
+
+// majorVersionOf keeps the first parts of a version, so that "6.2.6" has the major version "6" with one part
+func majorVersionOf(version string, parts int) string {
+	fields := strings.SplitN(version, ".", parts+1)
+	return strings.Join(fields[:min(parts, len(fields))], ".")
+}
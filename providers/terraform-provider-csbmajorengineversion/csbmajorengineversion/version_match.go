@@ -0,0 +1,69 @@
+package csbmajorengineversion
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// bestMatch picks one of the versions that were returned for the requested engine version, independently of
+// the order in which they were returned: the exact version if present, otherwise the highest available version
+// in the family of the requested version, otherwise the highest deprecated version in the family.
+// Versions outside the family are only considered when no version is in the family.
+func bestMatch(engineVersion string, versions []EngineVersion) (EngineVersion, bool) {
+	if i := slices.IndexFunc(versions, func(v EngineVersion) bool { return v.Version == engineVersion }); i >= 0 {
+		return versions[i], true
+	}
+
+	candidates := slices.DeleteFunc(slices.Clone(versions), func(v EngineVersion) bool {
+		return !strings.HasPrefix(v.Version, engineVersion+".")
+	})
+	if len(candidates) == 0 {
+		candidates = versions
+	}
+	if len(candidates) == 0 {
+		return EngineVersion{}, false
+	}
+
+	return slices.MaxFunc(candidates, func(a, b EngineVersion) int {
+		return cmp.Or(
+			compareAvailability(a.Status, b.Status),
+			compareVersions(a.Version, b.Version),
+		)
+	}), true
+}
+
+// compareAvailability ranks available versions above deprecated ones
+func compareAvailability(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == availableStatus:
+		return 1
+	case b == availableStatus:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// compareVersions compares the dot separated parts of two versions, numerically when both parts are numbers,
+// so that "14.10" is higher than "14.9" and "8.0.mysql_aurora.3.04.0" is higher than "8.0.mysql_aurora.3.03.1"
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range min(len(aParts), len(bParts)) {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(aNumber, bNumber)
+		} else {
+			c = strings.Compare(aParts[i], bParts[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aParts), len(bParts))
+}
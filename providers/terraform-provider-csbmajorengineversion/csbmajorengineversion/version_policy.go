@@ -0,0 +1,54 @@
+package csbmajorengineversion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	deprecatedStatus          = "deprecated"
+	defaultSupportWarningDays = 180
+)
+
+// versionPolicy decides how versions that are deprecated, or at the end of standard support, are reported
+type versionPolicy struct {
+	failOnDeprecated bool
+	warningPeriod    time.Duration
+}
+
+// check returns a diagnostic for a version that is deprecated, past the end of standard support, or close to it.
+// Versions past the end of standard support incur RDS Extended Support charges, so they are treated as deprecated.
+func (p versionPolicy) check(engine string, version EngineVersion, endOfSupport, now time.Time) diag.Diagnostics {
+	severity := diag.Warning
+	if p.failOnDeprecated {
+		severity = diag.Error
+	}
+
+	engineVersion := version.Version
+	switch {
+	case version.Status == deprecatedStatus:
+		return diag.Diagnostics{{
+			Severity: severity,
+			Summary:  fmt.Sprintf("%s version %s is deprecated", engine, engineVersion),
+			Detail:   "Deprecated versions cannot be used to create new instances. Consider a newer version.",
+		}}
+	case endOfSupport.IsZero():
+		return nil
+	case !now.Before(endOfSupport):
+		return diag.Diagnostics{{
+			Severity: severity,
+			Summary:  fmt.Sprintf("%s version %s reached the end of RDS standard support on %s", engine, engineVersion, endOfSupport.Format(time.DateOnly)),
+			Detail:   "Instances running this version incur RDS Extended Support charges. Consider a newer major version.",
+		}}
+	case endOfSupport.Sub(now) <= p.warningPeriod:
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s version %s reaches the end of RDS standard support on %s", engine, engineVersion, endOfSupport.Format(time.DateOnly)),
+			Detail:   "Instances running this version will then incur RDS Extended Support charges. Consider a newer major version.",
+		}}
+	default:
+		return nil
+	}
+}
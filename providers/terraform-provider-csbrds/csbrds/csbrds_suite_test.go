@@ -0,0 +1,13 @@
+package csbrds_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCsbrds(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CSB RDS Suite")
+}
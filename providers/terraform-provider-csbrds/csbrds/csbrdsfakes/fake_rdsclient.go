@@ -0,0 +1,871 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbrdsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-rds/csbrds"
+)
+
+type FakeRDSClient struct {
+	CopyDBClusterSnapshotStub        func(context.Context, *rds.CopyDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBClusterSnapshotOutput, error)
+	copyDBClusterSnapshotMutex       sync.RWMutex
+	copyDBClusterSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.CopyDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	copyDBClusterSnapshotReturns struct {
+		result1 *rds.CopyDBClusterSnapshotOutput
+		result2 error
+	}
+	copyDBClusterSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.CopyDBClusterSnapshotOutput
+		result2 error
+	}
+	CopyDBSnapshotStub        func(context.Context, *rds.CopyDBSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error)
+	copyDBSnapshotMutex       sync.RWMutex
+	copyDBSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.CopyDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	copyDBSnapshotReturns struct {
+		result1 *rds.CopyDBSnapshotOutput
+		result2 error
+	}
+	copyDBSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.CopyDBSnapshotOutput
+		result2 error
+	}
+	CreateDBClusterSnapshotStub        func(context.Context, *rds.CreateDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error)
+	createDBClusterSnapshotMutex       sync.RWMutex
+	createDBClusterSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.CreateDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	createDBClusterSnapshotReturns struct {
+		result1 *rds.CreateDBClusterSnapshotOutput
+		result2 error
+	}
+	createDBClusterSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.CreateDBClusterSnapshotOutput
+		result2 error
+	}
+	CreateDBSnapshotStub        func(context.Context, *rds.CreateDBSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error)
+	createDBSnapshotMutex       sync.RWMutex
+	createDBSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.CreateDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	createDBSnapshotReturns struct {
+		result1 *rds.CreateDBSnapshotOutput
+		result2 error
+	}
+	createDBSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.CreateDBSnapshotOutput
+		result2 error
+	}
+	DeleteDBClusterSnapshotStub        func(context.Context, *rds.DeleteDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBClusterSnapshotOutput, error)
+	deleteDBClusterSnapshotMutex       sync.RWMutex
+	deleteDBClusterSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DeleteDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	deleteDBClusterSnapshotReturns struct {
+		result1 *rds.DeleteDBClusterSnapshotOutput
+		result2 error
+	}
+	deleteDBClusterSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.DeleteDBClusterSnapshotOutput
+		result2 error
+	}
+	DeleteDBSnapshotStub        func(context.Context, *rds.DeleteDBSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBSnapshotOutput, error)
+	deleteDBSnapshotMutex       sync.RWMutex
+	deleteDBSnapshotArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DeleteDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}
+	deleteDBSnapshotReturns struct {
+		result1 *rds.DeleteDBSnapshotOutput
+		result2 error
+	}
+	deleteDBSnapshotReturnsOnCall map[int]struct {
+		result1 *rds.DeleteDBSnapshotOutput
+		result2 error
+	}
+	DescribeDBClusterSnapshotsStub        func(context.Context, *rds.DescribeDBClusterSnapshotsInput, ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error)
+	describeDBClusterSnapshotsMutex       sync.RWMutex
+	describeDBClusterSnapshotsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBClusterSnapshotsInput
+		arg3 []func(*rds.Options)
+	}
+	describeDBClusterSnapshotsReturns struct {
+		result1 *rds.DescribeDBClusterSnapshotsOutput
+		result2 error
+	}
+	describeDBClusterSnapshotsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeDBClusterSnapshotsOutput
+		result2 error
+	}
+	DescribeDBSnapshotsStub        func(context.Context, *rds.DescribeDBSnapshotsInput, ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error)
+	describeDBSnapshotsMutex       sync.RWMutex
+	describeDBSnapshotsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBSnapshotsInput
+		arg3 []func(*rds.Options)
+	}
+	describeDBSnapshotsReturns struct {
+		result1 *rds.DescribeDBSnapshotsOutput
+		result2 error
+	}
+	describeDBSnapshotsReturnsOnCall map[int]struct {
+		result1 *rds.DescribeDBSnapshotsOutput
+		result2 error
+	}
+	ModifyDBClusterSnapshotAttributeStub        func(context.Context, *rds.ModifyDBClusterSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBClusterSnapshotAttributeOutput, error)
+	modifyDBClusterSnapshotAttributeMutex       sync.RWMutex
+	modifyDBClusterSnapshotAttributeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.ModifyDBClusterSnapshotAttributeInput
+		arg3 []func(*rds.Options)
+	}
+	modifyDBClusterSnapshotAttributeReturns struct {
+		result1 *rds.ModifyDBClusterSnapshotAttributeOutput
+		result2 error
+	}
+	modifyDBClusterSnapshotAttributeReturnsOnCall map[int]struct {
+		result1 *rds.ModifyDBClusterSnapshotAttributeOutput
+		result2 error
+	}
+	ModifyDBSnapshotAttributeStub        func(context.Context, *rds.ModifyDBSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBSnapshotAttributeOutput, error)
+	modifyDBSnapshotAttributeMutex       sync.RWMutex
+	modifyDBSnapshotAttributeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *rds.ModifyDBSnapshotAttributeInput
+		arg3 []func(*rds.Options)
+	}
+	modifyDBSnapshotAttributeReturns struct {
+		result1 *rds.ModifyDBSnapshotAttributeOutput
+		result2 error
+	}
+	modifyDBSnapshotAttributeReturnsOnCall map[int]struct {
+		result1 *rds.ModifyDBSnapshotAttributeOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshot(arg1 context.Context, arg2 *rds.CopyDBClusterSnapshotInput, arg3 ...func(*rds.Options)) (*rds.CopyDBClusterSnapshotOutput, error) {
+	fake.copyDBClusterSnapshotMutex.Lock()
+	ret, specificReturn := fake.copyDBClusterSnapshotReturnsOnCall[len(fake.copyDBClusterSnapshotArgsForCall)]
+	fake.copyDBClusterSnapshotArgsForCall = append(fake.copyDBClusterSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.CopyDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CopyDBClusterSnapshotStub
+	fakeReturns := fake.copyDBClusterSnapshotReturns
+	fake.recordInvocation("CopyDBClusterSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.copyDBClusterSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshotCallCount() int {
+	fake.copyDBClusterSnapshotMutex.RLock()
+	defer fake.copyDBClusterSnapshotMutex.RUnlock()
+	return len(fake.copyDBClusterSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshotCalls(stub func(context.Context, *rds.CopyDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBClusterSnapshotOutput, error)) {
+	fake.copyDBClusterSnapshotMutex.Lock()
+	defer fake.copyDBClusterSnapshotMutex.Unlock()
+	fake.CopyDBClusterSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshotArgsForCall(i int) (context.Context, *rds.CopyDBClusterSnapshotInput, []func(*rds.Options)) {
+	fake.copyDBClusterSnapshotMutex.RLock()
+	defer fake.copyDBClusterSnapshotMutex.RUnlock()
+	argsForCall := fake.copyDBClusterSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshotReturns(result1 *rds.CopyDBClusterSnapshotOutput, result2 error) {
+	fake.copyDBClusterSnapshotMutex.Lock()
+	defer fake.copyDBClusterSnapshotMutex.Unlock()
+	fake.CopyDBClusterSnapshotStub = nil
+	fake.copyDBClusterSnapshotReturns = struct {
+		result1 *rds.CopyDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CopyDBClusterSnapshotReturnsOnCall(i int, result1 *rds.CopyDBClusterSnapshotOutput, result2 error) {
+	fake.copyDBClusterSnapshotMutex.Lock()
+	defer fake.copyDBClusterSnapshotMutex.Unlock()
+	fake.CopyDBClusterSnapshotStub = nil
+	if fake.copyDBClusterSnapshotReturnsOnCall == nil {
+		fake.copyDBClusterSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.CopyDBClusterSnapshotOutput
+			result2 error
+		})
+	}
+	fake.copyDBClusterSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.CopyDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshot(arg1 context.Context, arg2 *rds.CopyDBSnapshotInput, arg3 ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error) {
+	fake.copyDBSnapshotMutex.Lock()
+	ret, specificReturn := fake.copyDBSnapshotReturnsOnCall[len(fake.copyDBSnapshotArgsForCall)]
+	fake.copyDBSnapshotArgsForCall = append(fake.copyDBSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.CopyDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CopyDBSnapshotStub
+	fakeReturns := fake.copyDBSnapshotReturns
+	fake.recordInvocation("CopyDBSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.copyDBSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshotCallCount() int {
+	fake.copyDBSnapshotMutex.RLock()
+	defer fake.copyDBSnapshotMutex.RUnlock()
+	return len(fake.copyDBSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshotCalls(stub func(context.Context, *rds.CopyDBSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error)) {
+	fake.copyDBSnapshotMutex.Lock()
+	defer fake.copyDBSnapshotMutex.Unlock()
+	fake.CopyDBSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshotArgsForCall(i int) (context.Context, *rds.CopyDBSnapshotInput, []func(*rds.Options)) {
+	fake.copyDBSnapshotMutex.RLock()
+	defer fake.copyDBSnapshotMutex.RUnlock()
+	argsForCall := fake.copyDBSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshotReturns(result1 *rds.CopyDBSnapshotOutput, result2 error) {
+	fake.copyDBSnapshotMutex.Lock()
+	defer fake.copyDBSnapshotMutex.Unlock()
+	fake.CopyDBSnapshotStub = nil
+	fake.copyDBSnapshotReturns = struct {
+		result1 *rds.CopyDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CopyDBSnapshotReturnsOnCall(i int, result1 *rds.CopyDBSnapshotOutput, result2 error) {
+	fake.copyDBSnapshotMutex.Lock()
+	defer fake.copyDBSnapshotMutex.Unlock()
+	fake.CopyDBSnapshotStub = nil
+	if fake.copyDBSnapshotReturnsOnCall == nil {
+		fake.copyDBSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.CopyDBSnapshotOutput
+			result2 error
+		})
+	}
+	fake.copyDBSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.CopyDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshot(arg1 context.Context, arg2 *rds.CreateDBClusterSnapshotInput, arg3 ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error) {
+	fake.createDBClusterSnapshotMutex.Lock()
+	ret, specificReturn := fake.createDBClusterSnapshotReturnsOnCall[len(fake.createDBClusterSnapshotArgsForCall)]
+	fake.createDBClusterSnapshotArgsForCall = append(fake.createDBClusterSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.CreateDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDBClusterSnapshotStub
+	fakeReturns := fake.createDBClusterSnapshotReturns
+	fake.recordInvocation("CreateDBClusterSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.createDBClusterSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshotCallCount() int {
+	fake.createDBClusterSnapshotMutex.RLock()
+	defer fake.createDBClusterSnapshotMutex.RUnlock()
+	return len(fake.createDBClusterSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshotCalls(stub func(context.Context, *rds.CreateDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error)) {
+	fake.createDBClusterSnapshotMutex.Lock()
+	defer fake.createDBClusterSnapshotMutex.Unlock()
+	fake.CreateDBClusterSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshotArgsForCall(i int) (context.Context, *rds.CreateDBClusterSnapshotInput, []func(*rds.Options)) {
+	fake.createDBClusterSnapshotMutex.RLock()
+	defer fake.createDBClusterSnapshotMutex.RUnlock()
+	argsForCall := fake.createDBClusterSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshotReturns(result1 *rds.CreateDBClusterSnapshotOutput, result2 error) {
+	fake.createDBClusterSnapshotMutex.Lock()
+	defer fake.createDBClusterSnapshotMutex.Unlock()
+	fake.CreateDBClusterSnapshotStub = nil
+	fake.createDBClusterSnapshotReturns = struct {
+		result1 *rds.CreateDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CreateDBClusterSnapshotReturnsOnCall(i int, result1 *rds.CreateDBClusterSnapshotOutput, result2 error) {
+	fake.createDBClusterSnapshotMutex.Lock()
+	defer fake.createDBClusterSnapshotMutex.Unlock()
+	fake.CreateDBClusterSnapshotStub = nil
+	if fake.createDBClusterSnapshotReturnsOnCall == nil {
+		fake.createDBClusterSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.CreateDBClusterSnapshotOutput
+			result2 error
+		})
+	}
+	fake.createDBClusterSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.CreateDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshot(arg1 context.Context, arg2 *rds.CreateDBSnapshotInput, arg3 ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error) {
+	fake.createDBSnapshotMutex.Lock()
+	ret, specificReturn := fake.createDBSnapshotReturnsOnCall[len(fake.createDBSnapshotArgsForCall)]
+	fake.createDBSnapshotArgsForCall = append(fake.createDBSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.CreateDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDBSnapshotStub
+	fakeReturns := fake.createDBSnapshotReturns
+	fake.recordInvocation("CreateDBSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.createDBSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshotCallCount() int {
+	fake.createDBSnapshotMutex.RLock()
+	defer fake.createDBSnapshotMutex.RUnlock()
+	return len(fake.createDBSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshotCalls(stub func(context.Context, *rds.CreateDBSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error)) {
+	fake.createDBSnapshotMutex.Lock()
+	defer fake.createDBSnapshotMutex.Unlock()
+	fake.CreateDBSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshotArgsForCall(i int) (context.Context, *rds.CreateDBSnapshotInput, []func(*rds.Options)) {
+	fake.createDBSnapshotMutex.RLock()
+	defer fake.createDBSnapshotMutex.RUnlock()
+	argsForCall := fake.createDBSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshotReturns(result1 *rds.CreateDBSnapshotOutput, result2 error) {
+	fake.createDBSnapshotMutex.Lock()
+	defer fake.createDBSnapshotMutex.Unlock()
+	fake.CreateDBSnapshotStub = nil
+	fake.createDBSnapshotReturns = struct {
+		result1 *rds.CreateDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) CreateDBSnapshotReturnsOnCall(i int, result1 *rds.CreateDBSnapshotOutput, result2 error) {
+	fake.createDBSnapshotMutex.Lock()
+	defer fake.createDBSnapshotMutex.Unlock()
+	fake.CreateDBSnapshotStub = nil
+	if fake.createDBSnapshotReturnsOnCall == nil {
+		fake.createDBSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.CreateDBSnapshotOutput
+			result2 error
+		})
+	}
+	fake.createDBSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.CreateDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshot(arg1 context.Context, arg2 *rds.DeleteDBClusterSnapshotInput, arg3 ...func(*rds.Options)) (*rds.DeleteDBClusterSnapshotOutput, error) {
+	fake.deleteDBClusterSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteDBClusterSnapshotReturnsOnCall[len(fake.deleteDBClusterSnapshotArgsForCall)]
+	fake.deleteDBClusterSnapshotArgsForCall = append(fake.deleteDBClusterSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DeleteDBClusterSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteDBClusterSnapshotStub
+	fakeReturns := fake.deleteDBClusterSnapshotReturns
+	fake.recordInvocation("DeleteDBClusterSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.deleteDBClusterSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshotCallCount() int {
+	fake.deleteDBClusterSnapshotMutex.RLock()
+	defer fake.deleteDBClusterSnapshotMutex.RUnlock()
+	return len(fake.deleteDBClusterSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshotCalls(stub func(context.Context, *rds.DeleteDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBClusterSnapshotOutput, error)) {
+	fake.deleteDBClusterSnapshotMutex.Lock()
+	defer fake.deleteDBClusterSnapshotMutex.Unlock()
+	fake.DeleteDBClusterSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshotArgsForCall(i int) (context.Context, *rds.DeleteDBClusterSnapshotInput, []func(*rds.Options)) {
+	fake.deleteDBClusterSnapshotMutex.RLock()
+	defer fake.deleteDBClusterSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteDBClusterSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshotReturns(result1 *rds.DeleteDBClusterSnapshotOutput, result2 error) {
+	fake.deleteDBClusterSnapshotMutex.Lock()
+	defer fake.deleteDBClusterSnapshotMutex.Unlock()
+	fake.DeleteDBClusterSnapshotStub = nil
+	fake.deleteDBClusterSnapshotReturns = struct {
+		result1 *rds.DeleteDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DeleteDBClusterSnapshotReturnsOnCall(i int, result1 *rds.DeleteDBClusterSnapshotOutput, result2 error) {
+	fake.deleteDBClusterSnapshotMutex.Lock()
+	defer fake.deleteDBClusterSnapshotMutex.Unlock()
+	fake.DeleteDBClusterSnapshotStub = nil
+	if fake.deleteDBClusterSnapshotReturnsOnCall == nil {
+		fake.deleteDBClusterSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.DeleteDBClusterSnapshotOutput
+			result2 error
+		})
+	}
+	fake.deleteDBClusterSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.DeleteDBClusterSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshot(arg1 context.Context, arg2 *rds.DeleteDBSnapshotInput, arg3 ...func(*rds.Options)) (*rds.DeleteDBSnapshotOutput, error) {
+	fake.deleteDBSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteDBSnapshotReturnsOnCall[len(fake.deleteDBSnapshotArgsForCall)]
+	fake.deleteDBSnapshotArgsForCall = append(fake.deleteDBSnapshotArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DeleteDBSnapshotInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteDBSnapshotStub
+	fakeReturns := fake.deleteDBSnapshotReturns
+	fake.recordInvocation("DeleteDBSnapshot", []interface{}{arg1, arg2, arg3})
+	fake.deleteDBSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshotCallCount() int {
+	fake.deleteDBSnapshotMutex.RLock()
+	defer fake.deleteDBSnapshotMutex.RUnlock()
+	return len(fake.deleteDBSnapshotArgsForCall)
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshotCalls(stub func(context.Context, *rds.DeleteDBSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBSnapshotOutput, error)) {
+	fake.deleteDBSnapshotMutex.Lock()
+	defer fake.deleteDBSnapshotMutex.Unlock()
+	fake.DeleteDBSnapshotStub = stub
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshotArgsForCall(i int) (context.Context, *rds.DeleteDBSnapshotInput, []func(*rds.Options)) {
+	fake.deleteDBSnapshotMutex.RLock()
+	defer fake.deleteDBSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteDBSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshotReturns(result1 *rds.DeleteDBSnapshotOutput, result2 error) {
+	fake.deleteDBSnapshotMutex.Lock()
+	defer fake.deleteDBSnapshotMutex.Unlock()
+	fake.DeleteDBSnapshotStub = nil
+	fake.deleteDBSnapshotReturns = struct {
+		result1 *rds.DeleteDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DeleteDBSnapshotReturnsOnCall(i int, result1 *rds.DeleteDBSnapshotOutput, result2 error) {
+	fake.deleteDBSnapshotMutex.Lock()
+	defer fake.deleteDBSnapshotMutex.Unlock()
+	fake.DeleteDBSnapshotStub = nil
+	if fake.deleteDBSnapshotReturnsOnCall == nil {
+		fake.deleteDBSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *rds.DeleteDBSnapshotOutput
+			result2 error
+		})
+	}
+	fake.deleteDBSnapshotReturnsOnCall[i] = struct {
+		result1 *rds.DeleteDBSnapshotOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshots(arg1 context.Context, arg2 *rds.DescribeDBClusterSnapshotsInput, arg3 ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error) {
+	fake.describeDBClusterSnapshotsMutex.Lock()
+	ret, specificReturn := fake.describeDBClusterSnapshotsReturnsOnCall[len(fake.describeDBClusterSnapshotsArgsForCall)]
+	fake.describeDBClusterSnapshotsArgsForCall = append(fake.describeDBClusterSnapshotsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBClusterSnapshotsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeDBClusterSnapshotsStub
+	fakeReturns := fake.describeDBClusterSnapshotsReturns
+	fake.recordInvocation("DescribeDBClusterSnapshots", []interface{}{arg1, arg2, arg3})
+	fake.describeDBClusterSnapshotsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshotsCallCount() int {
+	fake.describeDBClusterSnapshotsMutex.RLock()
+	defer fake.describeDBClusterSnapshotsMutex.RUnlock()
+	return len(fake.describeDBClusterSnapshotsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshotsCalls(stub func(context.Context, *rds.DescribeDBClusterSnapshotsInput, ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error)) {
+	fake.describeDBClusterSnapshotsMutex.Lock()
+	defer fake.describeDBClusterSnapshotsMutex.Unlock()
+	fake.DescribeDBClusterSnapshotsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshotsArgsForCall(i int) (context.Context, *rds.DescribeDBClusterSnapshotsInput, []func(*rds.Options)) {
+	fake.describeDBClusterSnapshotsMutex.RLock()
+	defer fake.describeDBClusterSnapshotsMutex.RUnlock()
+	argsForCall := fake.describeDBClusterSnapshotsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshotsReturns(result1 *rds.DescribeDBClusterSnapshotsOutput, result2 error) {
+	fake.describeDBClusterSnapshotsMutex.Lock()
+	defer fake.describeDBClusterSnapshotsMutex.Unlock()
+	fake.DescribeDBClusterSnapshotsStub = nil
+	fake.describeDBClusterSnapshotsReturns = struct {
+		result1 *rds.DescribeDBClusterSnapshotsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBClusterSnapshotsReturnsOnCall(i int, result1 *rds.DescribeDBClusterSnapshotsOutput, result2 error) {
+	fake.describeDBClusterSnapshotsMutex.Lock()
+	defer fake.describeDBClusterSnapshotsMutex.Unlock()
+	fake.DescribeDBClusterSnapshotsStub = nil
+	if fake.describeDBClusterSnapshotsReturnsOnCall == nil {
+		fake.describeDBClusterSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeDBClusterSnapshotsOutput
+			result2 error
+		})
+	}
+	fake.describeDBClusterSnapshotsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeDBClusterSnapshotsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshots(arg1 context.Context, arg2 *rds.DescribeDBSnapshotsInput, arg3 ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error) {
+	fake.describeDBSnapshotsMutex.Lock()
+	ret, specificReturn := fake.describeDBSnapshotsReturnsOnCall[len(fake.describeDBSnapshotsArgsForCall)]
+	fake.describeDBSnapshotsArgsForCall = append(fake.describeDBSnapshotsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.DescribeDBSnapshotsInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeDBSnapshotsStub
+	fakeReturns := fake.describeDBSnapshotsReturns
+	fake.recordInvocation("DescribeDBSnapshots", []interface{}{arg1, arg2, arg3})
+	fake.describeDBSnapshotsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshotsCallCount() int {
+	fake.describeDBSnapshotsMutex.RLock()
+	defer fake.describeDBSnapshotsMutex.RUnlock()
+	return len(fake.describeDBSnapshotsArgsForCall)
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshotsCalls(stub func(context.Context, *rds.DescribeDBSnapshotsInput, ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error)) {
+	fake.describeDBSnapshotsMutex.Lock()
+	defer fake.describeDBSnapshotsMutex.Unlock()
+	fake.DescribeDBSnapshotsStub = stub
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshotsArgsForCall(i int) (context.Context, *rds.DescribeDBSnapshotsInput, []func(*rds.Options)) {
+	fake.describeDBSnapshotsMutex.RLock()
+	defer fake.describeDBSnapshotsMutex.RUnlock()
+	argsForCall := fake.describeDBSnapshotsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshotsReturns(result1 *rds.DescribeDBSnapshotsOutput, result2 error) {
+	fake.describeDBSnapshotsMutex.Lock()
+	defer fake.describeDBSnapshotsMutex.Unlock()
+	fake.DescribeDBSnapshotsStub = nil
+	fake.describeDBSnapshotsReturns = struct {
+		result1 *rds.DescribeDBSnapshotsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) DescribeDBSnapshotsReturnsOnCall(i int, result1 *rds.DescribeDBSnapshotsOutput, result2 error) {
+	fake.describeDBSnapshotsMutex.Lock()
+	defer fake.describeDBSnapshotsMutex.Unlock()
+	fake.DescribeDBSnapshotsStub = nil
+	if fake.describeDBSnapshotsReturnsOnCall == nil {
+		fake.describeDBSnapshotsReturnsOnCall = make(map[int]struct {
+			result1 *rds.DescribeDBSnapshotsOutput
+			result2 error
+		})
+	}
+	fake.describeDBSnapshotsReturnsOnCall[i] = struct {
+		result1 *rds.DescribeDBSnapshotsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttribute(arg1 context.Context, arg2 *rds.ModifyDBClusterSnapshotAttributeInput, arg3 ...func(*rds.Options)) (*rds.ModifyDBClusterSnapshotAttributeOutput, error) {
+	fake.modifyDBClusterSnapshotAttributeMutex.Lock()
+	ret, specificReturn := fake.modifyDBClusterSnapshotAttributeReturnsOnCall[len(fake.modifyDBClusterSnapshotAttributeArgsForCall)]
+	fake.modifyDBClusterSnapshotAttributeArgsForCall = append(fake.modifyDBClusterSnapshotAttributeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.ModifyDBClusterSnapshotAttributeInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ModifyDBClusterSnapshotAttributeStub
+	fakeReturns := fake.modifyDBClusterSnapshotAttributeReturns
+	fake.recordInvocation("ModifyDBClusterSnapshotAttribute", []interface{}{arg1, arg2, arg3})
+	fake.modifyDBClusterSnapshotAttributeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttributeCallCount() int {
+	fake.modifyDBClusterSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.RUnlock()
+	return len(fake.modifyDBClusterSnapshotAttributeArgsForCall)
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttributeCalls(stub func(context.Context, *rds.ModifyDBClusterSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBClusterSnapshotAttributeOutput, error)) {
+	fake.modifyDBClusterSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBClusterSnapshotAttributeStub = stub
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttributeArgsForCall(i int) (context.Context, *rds.ModifyDBClusterSnapshotAttributeInput, []func(*rds.Options)) {
+	fake.modifyDBClusterSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.RUnlock()
+	argsForCall := fake.modifyDBClusterSnapshotAttributeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttributeReturns(result1 *rds.ModifyDBClusterSnapshotAttributeOutput, result2 error) {
+	fake.modifyDBClusterSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBClusterSnapshotAttributeStub = nil
+	fake.modifyDBClusterSnapshotAttributeReturns = struct {
+		result1 *rds.ModifyDBClusterSnapshotAttributeOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) ModifyDBClusterSnapshotAttributeReturnsOnCall(i int, result1 *rds.ModifyDBClusterSnapshotAttributeOutput, result2 error) {
+	fake.modifyDBClusterSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBClusterSnapshotAttributeStub = nil
+	if fake.modifyDBClusterSnapshotAttributeReturnsOnCall == nil {
+		fake.modifyDBClusterSnapshotAttributeReturnsOnCall = make(map[int]struct {
+			result1 *rds.ModifyDBClusterSnapshotAttributeOutput
+			result2 error
+		})
+	}
+	fake.modifyDBClusterSnapshotAttributeReturnsOnCall[i] = struct {
+		result1 *rds.ModifyDBClusterSnapshotAttributeOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttribute(arg1 context.Context, arg2 *rds.ModifyDBSnapshotAttributeInput, arg3 ...func(*rds.Options)) (*rds.ModifyDBSnapshotAttributeOutput, error) {
+	fake.modifyDBSnapshotAttributeMutex.Lock()
+	ret, specificReturn := fake.modifyDBSnapshotAttributeReturnsOnCall[len(fake.modifyDBSnapshotAttributeArgsForCall)]
+	fake.modifyDBSnapshotAttributeArgsForCall = append(fake.modifyDBSnapshotAttributeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *rds.ModifyDBSnapshotAttributeInput
+		arg3 []func(*rds.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ModifyDBSnapshotAttributeStub
+	fakeReturns := fake.modifyDBSnapshotAttributeReturns
+	fake.recordInvocation("ModifyDBSnapshotAttribute", []interface{}{arg1, arg2, arg3})
+	fake.modifyDBSnapshotAttributeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttributeCallCount() int {
+	fake.modifyDBSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBSnapshotAttributeMutex.RUnlock()
+	return len(fake.modifyDBSnapshotAttributeArgsForCall)
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttributeCalls(stub func(context.Context, *rds.ModifyDBSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBSnapshotAttributeOutput, error)) {
+	fake.modifyDBSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBSnapshotAttributeStub = stub
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttributeArgsForCall(i int) (context.Context, *rds.ModifyDBSnapshotAttributeInput, []func(*rds.Options)) {
+	fake.modifyDBSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBSnapshotAttributeMutex.RUnlock()
+	argsForCall := fake.modifyDBSnapshotAttributeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttributeReturns(result1 *rds.ModifyDBSnapshotAttributeOutput, result2 error) {
+	fake.modifyDBSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBSnapshotAttributeStub = nil
+	fake.modifyDBSnapshotAttributeReturns = struct {
+		result1 *rds.ModifyDBSnapshotAttributeOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) ModifyDBSnapshotAttributeReturnsOnCall(i int, result1 *rds.ModifyDBSnapshotAttributeOutput, result2 error) {
+	fake.modifyDBSnapshotAttributeMutex.Lock()
+	defer fake.modifyDBSnapshotAttributeMutex.Unlock()
+	fake.ModifyDBSnapshotAttributeStub = nil
+	if fake.modifyDBSnapshotAttributeReturnsOnCall == nil {
+		fake.modifyDBSnapshotAttributeReturnsOnCall = make(map[int]struct {
+			result1 *rds.ModifyDBSnapshotAttributeOutput
+			result2 error
+		})
+	}
+	fake.modifyDBSnapshotAttributeReturnsOnCall[i] = struct {
+		result1 *rds.ModifyDBSnapshotAttributeOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copyDBClusterSnapshotMutex.RLock()
+	defer fake.copyDBClusterSnapshotMutex.RUnlock()
+	fake.copyDBSnapshotMutex.RLock()
+	defer fake.copyDBSnapshotMutex.RUnlock()
+	fake.createDBClusterSnapshotMutex.RLock()
+	defer fake.createDBClusterSnapshotMutex.RUnlock()
+	fake.createDBSnapshotMutex.RLock()
+	defer fake.createDBSnapshotMutex.RUnlock()
+	fake.deleteDBClusterSnapshotMutex.RLock()
+	defer fake.deleteDBClusterSnapshotMutex.RUnlock()
+	fake.deleteDBSnapshotMutex.RLock()
+	defer fake.deleteDBSnapshotMutex.RUnlock()
+	fake.describeDBClusterSnapshotsMutex.RLock()
+	defer fake.describeDBClusterSnapshotsMutex.RUnlock()
+	fake.describeDBSnapshotsMutex.RLock()
+	defer fake.describeDBSnapshotsMutex.RUnlock()
+	fake.modifyDBClusterSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBClusterSnapshotAttributeMutex.RUnlock()
+	fake.modifyDBSnapshotAttributeMutex.RLock()
+	defer fake.modifyDBSnapshotAttributeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRDSClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbrds.RDSClient = new(FakeRDSClient)
@@ -0,0 +1,269 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbrdsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-rds/csbrds"
+)
+
+type FakeRDSConfig struct {
+	GetClientStub        func(context.Context, csbrds.Credentials) (csbrds.RDSClient, error)
+	getClientMutex       sync.RWMutex
+	getClientArgsForCall []struct {
+		arg1 context.Context
+		arg2 csbrds.Credentials
+	}
+	getClientReturns struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}
+	getClientReturnsOnCall map[int]struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}
+	GetRegionStub        func() string
+	getRegionMutex       sync.RWMutex
+	getRegionArgsForCall []struct {
+	}
+	getRegionReturns struct {
+		result1 string
+	}
+	getRegionReturnsOnCall map[int]struct {
+		result1 string
+	}
+	GetRegionalClientStub        func(context.Context, string, csbrds.Credentials) (csbrds.RDSClient, error)
+	getRegionalClientMutex       sync.RWMutex
+	getRegionalClientArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 csbrds.Credentials
+	}
+	getRegionalClientReturns struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}
+	getRegionalClientReturnsOnCall map[int]struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRDSConfig) GetClient(arg1 context.Context, arg2 csbrds.Credentials) (csbrds.RDSClient, error) {
+	fake.getClientMutex.Lock()
+	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
+	fake.getClientArgsForCall = append(fake.getClientArgsForCall, struct {
+		arg1 context.Context
+		arg2 csbrds.Credentials
+	}{arg1, arg2})
+	stub := fake.GetClientStub
+	fakeReturns := fake.getClientReturns
+	fake.recordInvocation("GetClient", []interface{}{arg1, arg2})
+	fake.getClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSConfig) GetClientCallCount() int {
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	return len(fake.getClientArgsForCall)
+}
+
+func (fake *FakeRDSConfig) GetClientCalls(stub func(context.Context, csbrds.Credentials) (csbrds.RDSClient, error)) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = stub
+}
+
+func (fake *FakeRDSConfig) GetClientArgsForCall(i int) (context.Context, csbrds.Credentials) {
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	argsForCall := fake.getClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRDSConfig) GetClientReturns(result1 csbrds.RDSClient, result2 error) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	fake.getClientReturns = struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSConfig) GetClientReturnsOnCall(i int, result1 csbrds.RDSClient, result2 error) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	if fake.getClientReturnsOnCall == nil {
+		fake.getClientReturnsOnCall = make(map[int]struct {
+			result1 csbrds.RDSClient
+			result2 error
+		})
+	}
+	fake.getClientReturnsOnCall[i] = struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSConfig) GetRegion() string {
+	fake.getRegionMutex.Lock()
+	ret, specificReturn := fake.getRegionReturnsOnCall[len(fake.getRegionArgsForCall)]
+	fake.getRegionArgsForCall = append(fake.getRegionArgsForCall, struct {
+	}{})
+	stub := fake.GetRegionStub
+	fakeReturns := fake.getRegionReturns
+	fake.recordInvocation("GetRegion", []interface{}{})
+	fake.getRegionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRDSConfig) GetRegionCallCount() int {
+	fake.getRegionMutex.RLock()
+	defer fake.getRegionMutex.RUnlock()
+	return len(fake.getRegionArgsForCall)
+}
+
+func (fake *FakeRDSConfig) GetRegionCalls(stub func() string) {
+	fake.getRegionMutex.Lock()
+	defer fake.getRegionMutex.Unlock()
+	fake.GetRegionStub = stub
+}
+
+func (fake *FakeRDSConfig) GetRegionReturns(result1 string) {
+	fake.getRegionMutex.Lock()
+	defer fake.getRegionMutex.Unlock()
+	fake.GetRegionStub = nil
+	fake.getRegionReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRDSConfig) GetRegionReturnsOnCall(i int, result1 string) {
+	fake.getRegionMutex.Lock()
+	defer fake.getRegionMutex.Unlock()
+	fake.GetRegionStub = nil
+	if fake.getRegionReturnsOnCall == nil {
+		fake.getRegionReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.getRegionReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRDSConfig) GetRegionalClient(arg1 context.Context, arg2 string, arg3 csbrds.Credentials) (csbrds.RDSClient, error) {
+	fake.getRegionalClientMutex.Lock()
+	ret, specificReturn := fake.getRegionalClientReturnsOnCall[len(fake.getRegionalClientArgsForCall)]
+	fake.getRegionalClientArgsForCall = append(fake.getRegionalClientArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 csbrds.Credentials
+	}{arg1, arg2, arg3})
+	stub := fake.GetRegionalClientStub
+	fakeReturns := fake.getRegionalClientReturns
+	fake.recordInvocation("GetRegionalClient", []interface{}{arg1, arg2, arg3})
+	fake.getRegionalClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRDSConfig) GetRegionalClientCallCount() int {
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	return len(fake.getRegionalClientArgsForCall)
+}
+
+func (fake *FakeRDSConfig) GetRegionalClientCalls(stub func(context.Context, string, csbrds.Credentials) (csbrds.RDSClient, error)) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = stub
+}
+
+func (fake *FakeRDSConfig) GetRegionalClientArgsForCall(i int) (context.Context, string, csbrds.Credentials) {
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	argsForCall := fake.getRegionalClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRDSConfig) GetRegionalClientReturns(result1 csbrds.RDSClient, result2 error) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = nil
+	fake.getRegionalClientReturns = struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSConfig) GetRegionalClientReturnsOnCall(i int, result1 csbrds.RDSClient, result2 error) {
+	fake.getRegionalClientMutex.Lock()
+	defer fake.getRegionalClientMutex.Unlock()
+	fake.GetRegionalClientStub = nil
+	if fake.getRegionalClientReturnsOnCall == nil {
+		fake.getRegionalClientReturnsOnCall = make(map[int]struct {
+			result1 csbrds.RDSClient
+			result2 error
+		})
+	}
+	fake.getRegionalClientReturnsOnCall[i] = struct {
+		result1 csbrds.RDSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRDSConfig) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	fake.getRegionMutex.RLock()
+	defer fake.getRegionMutex.RUnlock()
+	fake.getRegionalClientMutex.RLock()
+	defer fake.getRegionalClientMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRDSConfig) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbrds.RDSConfig = new(FakeRDSConfig)
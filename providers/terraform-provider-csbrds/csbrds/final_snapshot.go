@@ -0,0 +1,147 @@
+package csbrds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// FinalSnapshotTagKey marks the snapshots taken by the provider, and records what they were taken of
+	FinalSnapshotTagKey    = "csb-final-snapshot"
+	InstanceIDTagKey       = "csb-instance-id"
+	OrganizationGUIDTagKey = "csb-organization-guid"
+	SpaceGUIDTagKey        = "csb-space-guid"
+
+	snapshotTimeFormat = "20060102150405"
+	statusAvailable    = "available"
+)
+
+// finalSnapshot is the lifecycle of the final snapshot of an instance or cluster
+type finalSnapshot struct {
+	kind         snapshotKind
+	sourceID     string
+	prefix       string
+	tags         []types.Tag
+	shareWith    []string
+	copyRegion   string
+	copyKMSKeyID string
+	retention    int
+	interval     time.Duration
+	timeout      time.Duration
+}
+
+func (f finalSnapshot) identifier(now time.Time) string {
+	return fmt.Sprintf("%s-%s-%s", f.prefix, f.sourceID, now.UTC().Format(snapshotTimeFormat))
+}
+
+// take takes the final snapshot and waits until it is available, then shares it, copies it, and prunes
+// the older final snapshots. Only a final snapshot that could not be taken is reported as an error.
+func (f finalSnapshot) take(ctx context.Context, settings RDSConfig, creds Credentials) (d diag.Diagnostics) {
+	client, err := settings.GetClient(ctx, creds)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	taken, err := f.kind.create(ctx, client, f.sourceID, f.identifier(time.Now()), f.tags)
+	switch {
+	case errors.Is(err, errSourceNotFound):
+		// There is nothing left to protect, and failing would prevent the service instance from being deleted
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: fmt.Sprintf("no final snapshot was taken because %s", err)}}
+	case err != nil:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: err.Error(), Detail: fmt.Sprintf("no final snapshot of %q was taken", f.sourceID)}}
+	}
+
+	if err := f.kind.waitAvailable(ctx, client, taken.identifier, f.interval, f.timeout); err != nil {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: err.Error(), Detail: fmt.Sprintf("final snapshot %q did not become available", taken.identifier)}}
+	}
+	d = append(d, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("final snapshot %q of %q was taken", taken.identifier, f.sourceID),
+		Detail:   taken.arn,
+	})
+
+	if len(f.shareWith) > 0 {
+		if err := f.kind.share(ctx, client, taken.identifier, f.shareWith); err != nil {
+			d = append(d, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  err.Error(),
+				Detail:   fmt.Sprintf("final snapshot %q was not shared with accounts %s", taken.identifier, strings.Join(f.shareWith, ", ")),
+			})
+		}
+	}
+
+	d = append(d, f.prune(ctx, client, settings.GetRegion())...)
+
+	if f.copyRegion != "" {
+		d = append(d, f.copy(ctx, settings, creds, taken)...)
+	}
+	return d
+}
+
+// copy starts a copy of the final snapshot in the disaster recovery region, without waiting for it to finish,
+// and prunes the older copies there
+func (f finalSnapshot) copy(ctx context.Context, settings RDSConfig, creds Credentials, taken snapshot) diag.Diagnostics {
+	client, err := settings.GetRegionalClient(ctx, f.copyRegion, creds)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("final snapshot %q was not copied to region %q", taken.identifier, f.copyRegion)}}
+	}
+
+	copied, err := f.kind.copy(ctx, client, taken, settings.GetRegion(), f.copyKMSKeyID)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("final snapshot %q was not copied to region %q", taken.identifier, f.copyRegion)}}
+	}
+
+	d := diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("final snapshot %q is being copied to region %q", taken.identifier, f.copyRegion),
+		Detail:   copied.arn,
+	}}
+	return append(d, f.prune(ctx, client, f.copyRegion)...)
+}
+
+// prune deletes the oldest final snapshots of the source with the prefix, so that the retention count is kept.
+// Snapshots that are not available, such as copies in progress, cannot be deleted, and are left for the next prune.
+func (f finalSnapshot) prune(ctx context.Context, client RDSClient, region string) (d diag.Diagnostics) {
+	if f.retention == 0 {
+		return nil
+	}
+
+	snapshots, err := f.kind.listManual(ctx, client)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("final snapshots in region %q were not pruned", region)}}
+	}
+
+	var finals []snapshot
+	for _, s := range snapshots {
+		if s.tags[FinalSnapshotTagKey] == f.sourceID && strings.HasPrefix(s.identifier, f.prefix+"-") {
+			finals = append(finals, s)
+		}
+	}
+	if len(finals) <= f.retention {
+		return nil
+	}
+
+	// Newest first
+	slices.SortFunc(finals, func(a, b snapshot) int {
+		return b.createdAt.Compare(a.createdAt)
+	})
+	for _, s := range finals[f.retention:] {
+		if s.status != statusAvailable {
+			continue
+		}
+
+		if err := f.kind.remove(ctx, client, s.identifier); err != nil {
+			d = append(d, diag.Diagnostic{Severity: diag.Warning, Summary: err.Error(), Detail: fmt.Sprintf("final snapshot %q in region %q was not pruned", s.identifier, region)})
+			continue
+		}
+		d = append(d, diag.Diagnostic{Severity: diag.Warning, Summary: fmt.Sprintf("final snapshot %q in region %q was pruned", s.identifier, region)})
+	}
+	return d
+}
@@ -0,0 +1,243 @@
+package csbrds
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	DBInstanceIdentifierKey     = "db_instance_identifier"
+	DBClusterIdentifierKey      = "db_cluster_identifier"
+	SnapshotIdentifierPrefixKey = "snapshot_identifier_prefix"
+	InstanceIDKey               = "instance_id"
+	OrganizationGUIDKey         = "organization_guid"
+	SpaceGUIDKey                = "space_guid"
+	TagsKey                     = "tags"
+	CopyToRegionKey             = "copy_to_region"
+	CopyKMSKeyIDKey             = "copy_kms_key_id"
+	ShareWithAccountsKey        = "share_with_accounts"
+	RetentionCountKey           = "retention_count"
+	PollIntervalKey             = "poll_interval"
+
+	defaultSnapshotIdentifierPrefix = "csb-final"
+	defaultPollInterval             = 30 * time.Second
+	defaultDeletionTimeout          = 60 * time.Minute
+)
+
+var (
+	// Snapshot identifiers start with a letter, and only have letters, digits and single hyphens
+	snapshotPrefixRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{0,99}$`)
+	invalidHyphensRegexp = regexp.MustCompile(`--|-$`)
+	accountIDRegexp      = regexp.MustCompile(`^\d{12}$`)
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbrdsfakes/header.txt . RDSClient
+type RDSClient interface {
+	CreateDBSnapshot(context.Context, *rds.CreateDBSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error)
+	rds.DescribeDBSnapshotsAPIClient
+	ModifyDBSnapshotAttribute(context.Context, *rds.ModifyDBSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBSnapshotAttributeOutput, error)
+	CopyDBSnapshot(context.Context, *rds.CopyDBSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error)
+	DeleteDBSnapshot(context.Context, *rds.DeleteDBSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBSnapshotOutput, error)
+	CreateDBClusterSnapshot(context.Context, *rds.CreateDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error)
+	rds.DescribeDBClusterSnapshotsAPIClient
+	ModifyDBClusterSnapshotAttribute(context.Context, *rds.ModifyDBClusterSnapshotAttributeInput, ...func(*rds.Options)) (*rds.ModifyDBClusterSnapshotAttributeOutput, error)
+	CopyDBClusterSnapshot(context.Context, *rds.CopyDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.CopyDBClusterSnapshotOutput, error)
+	DeleteDBClusterSnapshot(context.Context, *rds.DeleteDBClusterSnapshotInput, ...func(*rds.Options)) (*rds.DeleteDBClusterSnapshotOutput, error)
+}
+
+var _ RDSClient = &rds.Client{}
+
+func ResourceFinalSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			DBInstanceIdentifierKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{DBInstanceIdentifierKey, DBClusterIdentifierKey},
+				Description:  "Identifier of the RDS instance to snapshot when the resource is destroyed",
+			},
+			DBClusterIdentifierKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{DBInstanceIdentifierKey, DBClusterIdentifierKey},
+				Description:  "Identifier of the Aurora cluster to snapshot when the resource is destroyed",
+			},
+			SnapshotIdentifierPrefixKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultSnapshotIdentifierPrefix,
+				ValidateFunc: validation.All(
+					validation.StringMatch(snapshotPrefixRegexp, "must start with a letter, and only have up to 100 letters, digits and hyphens"),
+					validation.StringDoesNotMatch(invalidHyphensRegexp, "must not have two consecutive hyphens or end with a hyphen"),
+				),
+				Description: "Prefix of the identifier of the final snapshot, which also selects the final snapshots that are pruned together",
+			},
+			InstanceIDKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Service instance that the final snapshot is tagged with",
+			},
+			OrganizationGUIDKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Organization that the final snapshot is tagged with",
+			},
+			SpaceGUIDKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Space that the final snapshot is tagged with",
+			},
+			TagsKey: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Other tags of the final snapshot",
+			},
+			CopyToRegionKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regionRegexp, "must be a region"),
+				Description:  "Disaster recovery region that the final snapshot is copied to",
+			},
+			CopyKMSKeyIDKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{CopyToRegionKey},
+				Description:  "KMS key in the disaster recovery region that the copy of an encrypted final snapshot is encrypted with",
+			},
+			ShareWithAccountsKey: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringMatch(accountIDRegexp, "must be an AWS account ID")},
+				Description: "AWS accounts that can restore the final snapshot",
+			},
+			RetentionCountKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of final snapshots of the instance or cluster with the same prefix to keep in each region, including the new one. 0 keeps every final snapshot.",
+			},
+			PollIntervalKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultPollInterval.String(),
+				ValidateFunc: validatePollInterval,
+				Description:  "Initial interval between checks that the final snapshot is available",
+			},
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(defaultDeletionTimeout),
+		},
+		CreateContext: setResourceID,
+		UpdateContext: setResourceID,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: ResourceFinalSnapshotDelete,
+		Description:   "Takes a final snapshot of an RDS instance or Aurora cluster when it is destroyed, and manages the lifecycle of final snapshots",
+	}
+}
+
+func setResourceID(_ context.Context, data *schema.ResourceData, _ any) diag.Diagnostics {
+	data.SetId(sourceIdentifier(data))
+	return nil
+}
+
+// ResourceFinalSnapshotDelete takes the final snapshot. A failure to take it fails the destroy, so that the
+// instance or cluster is not deleted without a final snapshot, while failures to share, copy or prune are warnings.
+func ResourceFinalSnapshotDelete(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	var kind snapshotKind = instanceSnapshots{}
+	if data.Get(DBClusterIdentifierKey).(string) != "" {
+		kind = clusterSnapshots{}
+	}
+
+	var shareWith []string
+	for _, accountID := range data.Get(ShareWithAccountsKey).(*schema.Set).List() {
+		shareWith = append(shareWith, accountID.(string))
+	}
+	slices.Sort(shareWith)
+
+	f := finalSnapshot{
+		kind:         kind,
+		sourceID:     sourceIdentifier(data),
+		prefix:       data.Get(SnapshotIdentifierPrefixKey).(string),
+		tags:         snapshotTags(data),
+		shareWith:    shareWith,
+		copyRegion:   data.Get(CopyToRegionKey).(string),
+		copyKMSKeyID: data.Get(CopyKMSKeyIDKey).(string),
+		retention:    data.Get(RetentionCountKey).(int),
+		interval:     pollInterval(data),
+		timeout:      data.Timeout(schema.TimeoutDelete),
+	}
+
+	d := f.take(ctx, config.(RDSConfig), credentialsFromResourceData(data))
+	if len(d) > 0 {
+		return d
+	}
+	return nil
+}
+
+func sourceIdentifier(data *schema.ResourceData) string {
+	if clusterID := data.Get(DBClusterIdentifierKey).(string); clusterID != "" {
+		return clusterID
+	}
+	return data.Get(DBInstanceIdentifierKey).(string)
+}
+
+// snapshotTags marks the snapshot as a final snapshot of the source, and labels it with the service instance
+func snapshotTags(data *schema.ResourceData) []types.Tag {
+	tags := map[string]string{FinalSnapshotTagKey: sourceIdentifier(data)}
+	for key, value := range data.Get(TagsKey).(map[string]any) {
+		tags[key] = value.(string)
+	}
+	for key, tagKey := range map[string]string{
+		InstanceIDKey:       InstanceIDTagKey,
+		OrganizationGUIDKey: OrganizationGUIDTagKey,
+		SpaceGUIDKey:        SpaceGUIDTagKey,
+	} {
+		if value := data.Get(key).(string); value != "" {
+			tags[tagKey] = value
+		}
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	result := make([]types.Tag, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	return result
+}
+
+// pollInterval is the first wait of the snapshot waiter, which backs off up to its own maximum for a
+// large database. poll_interval has a default, so it is never empty.
+func pollInterval(data *schema.ResourceData) time.Duration {
+	interval, _ := time.ParseDuration(data.Get(PollIntervalKey).(string))
+	return interval
+}
+
+func validatePollInterval(i any, key string) (warnings []string, errs []error) {
+	if interval, err := time.ParseDuration(i.(string)); err != nil || interval <= 0 {
+		errs = append(errs, fmt.Errorf("expected %q to be a positive duration, such as %s, got %q", key, defaultPollInterval, i))
+	}
+	return warnings, errs
+}
@@ -0,0 +1,366 @@
+package csbrds_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-rds/csbrds"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-rds/csbrds/csbrdsfakes"
+)
+
+const (
+	instanceID = "csb-mysql-1234"
+	clusterID  = "csb-aurora-1234"
+)
+
+var _ = Describe("ResourceFinalSnapshot", func() {
+	var (
+		client   *csbrdsfakes.FakeRDSClient
+		drClient *csbrdsfakes.FakeRDSClient
+		config   *csbrdsfakes.FakeRDSConfig
+		resource *schema.Resource
+		raw      map[string]any
+		listed   []types.DBSnapshot
+	)
+
+	// finalSnapshot is a listed final snapshot taken on the day
+	finalSnapshot := func(identifier, status string, day int) types.DBSnapshot {
+		return types.DBSnapshot{
+			DBSnapshotIdentifier: aws.String(identifier),
+			Status:               aws.String(status),
+			SnapshotCreateTime:   aws.Time(time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)),
+			TagList:              []types.Tag{{Key: aws.String(csbrds.FinalSnapshotTagKey), Value: aws.String(instanceID)}},
+		}
+	}
+
+	// describeDBSnapshots answers the waiter with an available snapshot, and the listing with the listed snapshots
+	describeDBSnapshots := func(_ context.Context, input *rds.DescribeDBSnapshotsInput, _ ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error) {
+		if input.DBSnapshotIdentifier != nil {
+			return &rds.DescribeDBSnapshotsOutput{DBSnapshots: []types.DBSnapshot{{
+				DBSnapshotIdentifier: input.DBSnapshotIdentifier,
+				Status:               aws.String("available"),
+			}}}, nil
+		}
+		return &rds.DescribeDBSnapshotsOutput{DBSnapshots: listed}, nil
+	}
+
+	deleteSnapshot := func() diag.Diagnostics {
+		data := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, raw)
+		return resource.DeleteContext(context.TODO(), data, config)
+	}
+
+	deletedSnapshotIDs := func(client *csbrdsfakes.FakeRDSClient) []string {
+		var ids []string
+		for i := range client.DeleteDBSnapshotCallCount() {
+			_, input, _ := client.DeleteDBSnapshotArgsForCall(i)
+			ids = append(ids, aws.ToString(input.DBSnapshotIdentifier))
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		listed = nil
+
+		client = &csbrdsfakes.FakeRDSClient{}
+		client.CreateDBSnapshotStub = func(_ context.Context, input *rds.CreateDBSnapshotInput, _ ...func(*rds.Options)) (*rds.CreateDBSnapshotOutput, error) {
+			return &rds.CreateDBSnapshotOutput{DBSnapshot: &types.DBSnapshot{
+				DBSnapshotIdentifier: input.DBSnapshotIdentifier,
+				DBSnapshotArn:        aws.String("arn:aws:rds:us-west-2:123456789012:snapshot:" + aws.ToString(input.DBSnapshotIdentifier)),
+				Status:               aws.String("creating"),
+			}}, nil
+		}
+		client.DescribeDBSnapshotsStub = describeDBSnapshots
+		client.ModifyDBSnapshotAttributeReturns(&rds.ModifyDBSnapshotAttributeOutput{}, nil)
+		client.DeleteDBSnapshotReturns(&rds.DeleteDBSnapshotOutput{}, nil)
+
+		drClient = &csbrdsfakes.FakeRDSClient{}
+		drClient.CopyDBSnapshotStub = func(_ context.Context, input *rds.CopyDBSnapshotInput, _ ...func(*rds.Options)) (*rds.CopyDBSnapshotOutput, error) {
+			return &rds.CopyDBSnapshotOutput{DBSnapshot: &types.DBSnapshot{
+				DBSnapshotIdentifier: input.TargetDBSnapshotIdentifier,
+				DBSnapshotArn:        aws.String("arn:aws:rds:us-east-1:123456789012:snapshot:" + aws.ToString(input.TargetDBSnapshotIdentifier)),
+				Status:               aws.String("pending"),
+			}}, nil
+		}
+		drClient.DescribeDBSnapshotsStub = describeDBSnapshots
+		drClient.DeleteDBSnapshotReturns(&rds.DeleteDBSnapshotOutput{}, nil)
+
+		config = &csbrdsfakes.FakeRDSConfig{}
+		config.GetClientReturns(client, nil)
+		config.GetRegionalClientReturns(drClient, nil)
+		config.GetRegionReturns("us-west-2")
+
+		resource = csbrds.ResourceFinalSnapshot()
+		raw = map[string]any{
+			csbrds.DBInstanceIdentifierKey: instanceID,
+			csbrds.PollIntervalKey:         "1ms",
+		}
+	})
+
+	It("takes a tagged final snapshot of the instance and waits until it is available", func() {
+		raw[csbrds.InstanceIDKey] = "instance-guid"
+		raw[csbrds.OrganizationGUIDKey] = "org-guid"
+		raw[csbrds.SpaceGUIDKey] = "space-guid"
+		raw[csbrds.TagsKey] = map[string]any{"team": "data"}
+
+		d := deleteSnapshot()
+
+		Expect(client.CreateDBSnapshotCallCount()).To(Equal(1))
+		_, input, _ := client.CreateDBSnapshotArgsForCall(0)
+		Expect(aws.ToString(input.DBInstanceIdentifier)).To(Equal(instanceID))
+		Expect(aws.ToString(input.DBSnapshotIdentifier)).To(MatchRegexp(`^csb-final-csb-mysql-1234-\d{14}$`))
+		Expect(input.Tags).To(Equal([]types.Tag{
+			{Key: aws.String("csb-final-snapshot"), Value: aws.String(instanceID)},
+			{Key: aws.String("csb-instance-id"), Value: aws.String("instance-guid")},
+			{Key: aws.String("csb-organization-guid"), Value: aws.String("org-guid")},
+			{Key: aws.String("csb-space-guid"), Value: aws.String("space-guid")},
+			{Key: aws.String("team"), Value: aws.String("data")},
+		}))
+
+		Expect(client.DescribeDBSnapshotsCallCount()).To(Equal(1))
+		_, describeInput, _ := client.DescribeDBSnapshotsArgsForCall(0)
+		Expect(describeInput.DBSnapshotIdentifier).To(Equal(input.DBSnapshotIdentifier))
+
+		Expect(d).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Severity": Equal(diag.Warning),
+			"Summary":  MatchRegexp(`final snapshot "csb-final-csb-mysql-1234-\d{14}" of "csb-mysql-1234" was taken`),
+			"Detail":   HavePrefix("arn:aws:rds:us-west-2:123456789012:snapshot:csb-final-csb-mysql-1234-"),
+		})))
+		Expect(client.ModifyDBSnapshotAttributeCallCount()).To(BeZero())
+		Expect(config.GetRegionalClientCallCount()).To(BeZero())
+		Expect(client.DeleteDBSnapshotCallCount()).To(BeZero())
+	})
+
+	It("takes a final snapshot of a cluster", func() {
+		delete(raw, csbrds.DBInstanceIdentifierKey)
+		raw[csbrds.DBClusterIdentifierKey] = clusterID
+		raw[csbrds.SnapshotIdentifierPrefixKey] = "final"
+		client.CreateDBClusterSnapshotStub = func(_ context.Context, input *rds.CreateDBClusterSnapshotInput, _ ...func(*rds.Options)) (*rds.CreateDBClusterSnapshotOutput, error) {
+			return &rds.CreateDBClusterSnapshotOutput{DBClusterSnapshot: &types.DBClusterSnapshot{
+				DBClusterSnapshotIdentifier: input.DBClusterSnapshotIdentifier,
+				Status:                      aws.String("creating"),
+			}}, nil
+		}
+		client.DescribeDBClusterSnapshotsReturns(&rds.DescribeDBClusterSnapshotsOutput{DBClusterSnapshots: []types.DBClusterSnapshot{{
+			Status: aws.String("available"),
+		}}}, nil)
+
+		d := deleteSnapshot()
+
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Warning))
+		Expect(client.CreateDBSnapshotCallCount()).To(BeZero())
+		Expect(client.CreateDBClusterSnapshotCallCount()).To(Equal(1))
+		_, input, _ := client.CreateDBClusterSnapshotArgsForCall(0)
+		Expect(aws.ToString(input.DBClusterIdentifier)).To(Equal(clusterID))
+		Expect(aws.ToString(input.DBClusterSnapshotIdentifier)).To(MatchRegexp(`^final-csb-aurora-1234-\d{14}$`))
+		Expect(input.Tags).To(ContainElement(types.Tag{Key: aws.String("csb-final-snapshot"), Value: aws.String(clusterID)}))
+		Expect(client.DescribeDBClusterSnapshotsCallCount()).To(Equal(1))
+	})
+
+	It("only warns when the instance no longer exists", func() {
+		client.CreateDBSnapshotStub = nil
+		client.CreateDBSnapshotReturns(nil, &types.DBInstanceNotFoundFault{Message: aws.String("not found")})
+
+		d := deleteSnapshot()
+
+		Expect(d).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Severity": Equal(diag.Warning),
+			"Summary":  Equal(`no final snapshot was taken because DB instance "csb-mysql-1234" does not exist`),
+		})))
+		Expect(client.DescribeDBSnapshotsCallCount()).To(BeZero())
+	})
+
+	It("fails when the final snapshot cannot be taken", func() {
+		client.CreateDBSnapshotStub = nil
+		client.CreateDBSnapshotReturns(nil, errors.New("snapshot quota exceeded"))
+
+		d := deleteSnapshot()
+
+		Expect(d).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Severity": Equal(diag.Error),
+			"Summary":  Equal("snapshot quota exceeded"),
+			"Detail":   Equal(`no final snapshot of "csb-mysql-1234" was taken`),
+		})))
+	})
+
+	It("fails when the final snapshot does not become available", func() {
+		client.DescribeDBSnapshotsStub = nil
+		client.DescribeDBSnapshotsReturns(&rds.DescribeDBSnapshotsOutput{DBSnapshots: []types.DBSnapshot{{Status: aws.String("failed")}}}, nil)
+
+		d := deleteSnapshot()
+
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Error))
+		Expect(d[0].Detail).To(MatchRegexp(`final snapshot "csb-final-csb-mysql-1234-\d{14}" did not become available`))
+		Expect(client.ModifyDBSnapshotAttributeCallCount()).To(BeZero())
+	})
+
+	Describe("sharing", func() {
+		BeforeEach(func() {
+			raw[csbrds.ShareWithAccountsKey] = []any{"222222222222", "111111111111"}
+		})
+
+		It("lets the accounts restore the final snapshot", func() {
+			d := deleteSnapshot()
+
+			Expect(d).To(HaveLen(1))
+			Expect(client.ModifyDBSnapshotAttributeCallCount()).To(Equal(1))
+			_, input, _ := client.ModifyDBSnapshotAttributeArgsForCall(0)
+			Expect(aws.ToString(input.DBSnapshotIdentifier)).To(MatchRegexp(`^csb-final-csb-mysql-1234-\d{14}$`))
+			Expect(aws.ToString(input.AttributeName)).To(Equal("restore"))
+			Expect(input.ValuesToAdd).To(Equal([]string{"111111111111", "222222222222"}))
+		})
+
+		It("only warns when the final snapshot cannot be shared", func() {
+			client.ModifyDBSnapshotAttributeReturns(nil, errors.New("encrypted with the default key"))
+
+			d := deleteSnapshot()
+
+			Expect(d.HasError()).To(BeFalse())
+			Expect(d).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Warning),
+				"Summary":  Equal("encrypted with the default key"),
+				"Detail":   MatchRegexp(`final snapshot ".*" was not shared with accounts 111111111111, 222222222222`),
+			})))
+		})
+	})
+
+	Describe("copying", func() {
+		BeforeEach(func() {
+			raw[csbrds.CopyToRegionKey] = "us-east-1"
+			raw[csbrds.CopyKMSKeyIDKey] = "arn:aws:kms:us-east-1:123456789012:key/dr"
+		})
+
+		It("copies the final snapshot to the disaster recovery region", func() {
+			d := deleteSnapshot()
+
+			Expect(d.HasError()).To(BeFalse())
+			Expect(config.GetRegionalClientCallCount()).To(Equal(1))
+			_, region, _ := config.GetRegionalClientArgsForCall(0)
+			Expect(region).To(Equal("us-east-1"))
+
+			Expect(drClient.CopyDBSnapshotCallCount()).To(Equal(1))
+			_, input, _ := drClient.CopyDBSnapshotArgsForCall(0)
+			Expect(aws.ToString(input.SourceDBSnapshotIdentifier)).To(HavePrefix("arn:aws:rds:us-west-2:123456789012:snapshot:csb-final-csb-mysql-1234-"))
+			Expect(aws.ToString(input.TargetDBSnapshotIdentifier)).To(MatchRegexp(`^csb-final-csb-mysql-1234-\d{14}$`))
+			Expect(aws.ToString(input.SourceRegion)).To(Equal("us-west-2"))
+			Expect(aws.ToString(input.KmsKeyId)).To(Equal("arn:aws:kms:us-east-1:123456789012:key/dr"))
+			Expect(aws.ToBool(input.CopyTags)).To(BeTrue())
+
+			Expect(d).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Warning),
+				"Summary":  MatchRegexp(`final snapshot ".*" is being copied to region "us-east-1"`),
+				"Detail":   HavePrefix("arn:aws:rds:us-east-1:123456789012:snapshot:"),
+			})))
+		})
+
+		It("only warns when the final snapshot cannot be copied", func() {
+			drClient.CopyDBSnapshotStub = nil
+			drClient.CopyDBSnapshotReturns(nil, errors.New("KMS key not found"))
+
+			d := deleteSnapshot()
+
+			Expect(d.HasError()).To(BeFalse())
+			Expect(d).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Warning),
+				"Summary":  Equal("KMS key not found"),
+				"Detail":   MatchRegexp(`final snapshot ".*" was not copied to region "us-east-1"`),
+			})))
+		})
+	})
+
+	Describe("pruning", func() {
+		BeforeEach(func() {
+			raw[csbrds.RetentionCountKey] = 2
+			listed = []types.DBSnapshot{
+				finalSnapshot("csb-final-csb-mysql-1234-20261003000000", "available", 3),
+				finalSnapshot("csb-final-csb-mysql-1234-20261018000000", "available", 18),
+				finalSnapshot("csb-final-csb-mysql-1234-20261001000000", "available", 1),
+				finalSnapshot("csb-final-csb-mysql-1234-20261002000000", "copying", 2),
+				finalSnapshot("csb-final-csb-mysql-1234-20261010000000", "available", 10),
+				finalSnapshot("other-csb-mysql-1234-20260101000000", "available", 1),
+				{
+					DBSnapshotIdentifier: aws.String("csb-final-manual"),
+					Status:               aws.String("available"),
+					SnapshotCreateTime:   aws.Time(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			}
+		})
+
+		It("deletes the oldest available final snapshots beyond the retention count", func() {
+			d := deleteSnapshot()
+
+			Expect(d.HasError()).To(BeFalse())
+			_, input, _ := client.DescribeDBSnapshotsArgsForCall(1)
+			Expect(aws.ToString(input.SnapshotType)).To(Equal("manual"))
+			Expect(deletedSnapshotIDs(client)).To(Equal([]string{
+				"csb-final-csb-mysql-1234-20261003000000",
+				"csb-final-csb-mysql-1234-20261001000000",
+			}))
+			Expect(d).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Warning),
+				"Summary":  Equal(`final snapshot "csb-final-csb-mysql-1234-20261001000000" in region "us-west-2" was pruned`),
+			})))
+		})
+
+		It("keeps the final snapshots of other instances that share the prefix", func() {
+			other := func(identifier string, day int) types.DBSnapshot {
+				s := finalSnapshot(identifier, "available", day)
+				s.TagList = []types.Tag{{Key: aws.String(csbrds.FinalSnapshotTagKey), Value: aws.String("csb-mysql-5678")}}
+				return s
+			}
+			listed = append(listed,
+				other("csb-final-csb-mysql-5678-20250101000000", 1),
+				other("csb-final-csb-mysql-5678-20250102000000", 2),
+				other("csb-final-csb-mysql-5678-20250103000000", 3),
+			)
+
+			deleteSnapshot()
+
+			Expect(deletedSnapshotIDs(client)).To(Equal([]string{
+				"csb-final-csb-mysql-1234-20261003000000",
+				"csb-final-csb-mysql-1234-20261001000000",
+			}))
+		})
+
+		It("prunes the copies in the disaster recovery region", func() {
+			raw[csbrds.CopyToRegionKey] = "us-east-1"
+
+			deleteSnapshot()
+
+			Expect(deletedSnapshotIDs(drClient)).To(HaveLen(2))
+		})
+
+		It("keeps every final snapshot when the retention count is zero", func() {
+			raw[csbrds.RetentionCountKey] = 0
+
+			deleteSnapshot()
+
+			Expect(client.DescribeDBSnapshotsCallCount()).To(Equal(1))
+			Expect(client.DeleteDBSnapshotCallCount()).To(BeZero())
+		})
+
+		It("only warns when final snapshots cannot be pruned", func() {
+			client.DeleteDBSnapshotReturns(nil, errors.New("snapshot is being copied"))
+
+			d := deleteSnapshot()
+
+			Expect(d.HasError()).To(BeFalse())
+			Expect(d).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Severity": Equal(diag.Warning),
+				"Summary":  Equal("snapshot is being copied"),
+				"Detail":   Equal(`final snapshot "csb-final-csb-mysql-1234-20261003000000" in region "us-west-2" was not pruned`),
+			})))
+		})
+	})
+})
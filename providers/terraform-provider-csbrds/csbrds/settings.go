@@ -0,0 +1,57 @@
+package csbrds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+//counterfeiter:generate -header csbrdsfakes/header.txt . RDSConfig
+type RDSConfig interface {
+	GetClient(ctx context.Context, creds Credentials) (RDSClient, error)
+	GetRegionalClient(ctx context.Context, region string, creds Credentials) (RDSClient, error)
+	GetRegion() string
+}
+
+type rdsSettings struct {
+	region            string
+	customEndpointURL string
+}
+
+// Fail fast if the interface is not implemented
+var _ RDSConfig = &rdsSettings{}
+
+func (r *rdsSettings) GetRegion() string {
+	return r.region
+}
+
+func (r *rdsSettings) GetClient(ctx context.Context, creds Credentials) (RDSClient, error) {
+	return r.GetRegionalClient(ctx, r.region, creds)
+}
+
+func (r *rdsSettings) GetRegionalClient(ctx context.Context, region string, creds Credentials) (RDSClient, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(region),
+		config.WithCredentialsProvider(
+			aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(
+					creds.AccessKeyID,
+					creds.SecretAccessKey,
+					creds.SessionToken,
+				),
+			),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rds.NewFromConfig(cfg, func(o *rds.Options) {
+		if r.customEndpointURL != "" {
+			o.BaseEndpoint = aws.String(r.customEndpointURL)
+		}
+	}), nil
+}
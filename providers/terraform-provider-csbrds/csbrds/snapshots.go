@@ -0,0 +1,240 @@
+package csbrds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// restoreAttribute is the snapshot attribute that lists the accounts that can restore a snapshot
+const restoreAttribute = "restore"
+
+// snapshot is what the final snapshot lifecycle needs to know about DB snapshots and DB cluster snapshots
+type snapshot struct {
+	identifier string
+	arn        string
+	status     string
+	createdAt  time.Time
+	tags       map[string]string
+}
+
+// snapshotKind handles either the DB snapshots of RDS instances, or the DB cluster snapshots of
+// Aurora clusters, which have separate but parallel APIs
+type snapshotKind interface {
+	// create returns errSourceNotFound when the source no longer exists
+	create(ctx context.Context, client RDSClient, sourceID, snapshotID string, tags []types.Tag) (snapshot, error)
+	waitAvailable(ctx context.Context, client RDSClient, snapshotID string, interval, timeout time.Duration) error
+	share(ctx context.Context, client RDSClient, snapshotID string, accountIDs []string) error
+	// copy copies a snapshot of another region into the region of the client
+	copy(ctx context.Context, client RDSClient, source snapshot, sourceRegion, kmsKeyID string) (snapshot, error)
+	listManual(ctx context.Context, client RDSClient) ([]snapshot, error)
+	remove(ctx context.Context, client RDSClient, snapshotID string) error
+}
+
+var errSourceNotFound = errors.New("does not exist")
+
+type instanceSnapshots struct{}
+
+// Fail fast if the interface is not implemented
+var _ snapshotKind = instanceSnapshots{}
+
+func (instanceSnapshots) create(ctx context.Context, client RDSClient, sourceID, snapshotID string, tags []types.Tag) (snapshot, error) {
+	output, err := client.CreateDBSnapshot(ctx, &rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(sourceID),
+		DBSnapshotIdentifier: aws.String(snapshotID),
+		Tags:                 tags,
+	})
+	var notFound *types.DBInstanceNotFoundFault
+	switch {
+	case errors.As(err, &notFound):
+		return snapshot{}, fmt.Errorf("DB instance %q %w", sourceID, errSourceNotFound)
+	case err != nil:
+		return snapshot{}, err
+	case output == nil || output.DBSnapshot == nil:
+		return snapshot{}, fmt.Errorf("no DB snapshot returned for DB instance %q", sourceID)
+	default:
+		return snapshotOfDBSnapshot(*output.DBSnapshot), nil
+	}
+}
+
+func (instanceSnapshots) waitAvailable(ctx context.Context, client RDSClient, snapshotID string, interval, timeout time.Duration) error {
+	return rds.NewDBSnapshotAvailableWaiter(client, func(o *rds.DBSnapshotAvailableWaiterOptions) {
+		o.MinDelay = interval
+		o.MaxDelay = max(interval, o.MaxDelay)
+	}).Wait(ctx, &rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: aws.String(snapshotID)}, timeout)
+}
+
+func (instanceSnapshots) share(ctx context.Context, client RDSClient, snapshotID string, accountIDs []string) error {
+	_, err := client.ModifyDBSnapshotAttribute(ctx, &rds.ModifyDBSnapshotAttributeInput{
+		DBSnapshotIdentifier: aws.String(snapshotID),
+		AttributeName:        aws.String(restoreAttribute),
+		ValuesToAdd:          accountIDs,
+	})
+	return err
+}
+
+func (instanceSnapshots) copy(ctx context.Context, client RDSClient, source snapshot, sourceRegion, kmsKeyID string) (snapshot, error) {
+	output, err := client.CopyDBSnapshot(ctx, &rds.CopyDBSnapshotInput{
+		SourceDBSnapshotIdentifier: aws.String(source.arn),
+		TargetDBSnapshotIdentifier: aws.String(source.identifier),
+		SourceRegion:               aws.String(sourceRegion),
+		KmsKeyId:                   optionalString(kmsKeyID),
+		CopyTags:                   aws.Bool(true),
+	})
+	switch {
+	case err != nil:
+		return snapshot{}, err
+	case output == nil || output.DBSnapshot == nil:
+		return snapshot{}, fmt.Errorf("no DB snapshot returned for the copy of %q", source.identifier)
+	default:
+		return snapshotOfDBSnapshot(*output.DBSnapshot), nil
+	}
+}
+
+func (instanceSnapshots) listManual(ctx context.Context, client RDSClient) ([]snapshot, error) {
+	var snapshots []snapshot
+	paginator := rds.NewDescribeDBSnapshotsPaginator(client, &rds.DescribeDBSnapshotsInput{SnapshotType: aws.String("manual")})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range page.DBSnapshots {
+			snapshots = append(snapshots, snapshotOfDBSnapshot(s))
+		}
+	}
+	return snapshots, nil
+}
+
+func (instanceSnapshots) remove(ctx context.Context, client RDSClient, snapshotID string) error {
+	_, err := client.DeleteDBSnapshot(ctx, &rds.DeleteDBSnapshotInput{DBSnapshotIdentifier: aws.String(snapshotID)})
+	var notFound *types.DBSnapshotNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+type clusterSnapshots struct{}
+
+// Fail fast if the interface is not implemented
+var _ snapshotKind = clusterSnapshots{}
+
+func (clusterSnapshots) create(ctx context.Context, client RDSClient, sourceID, snapshotID string, tags []types.Tag) (snapshot, error) {
+	output, err := client.CreateDBClusterSnapshot(ctx, &rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         aws.String(sourceID),
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+		Tags:                        tags,
+	})
+	var notFound *types.DBClusterNotFoundFault
+	switch {
+	case errors.As(err, &notFound):
+		return snapshot{}, fmt.Errorf("DB cluster %q %w", sourceID, errSourceNotFound)
+	case err != nil:
+		return snapshot{}, err
+	case output == nil || output.DBClusterSnapshot == nil:
+		return snapshot{}, fmt.Errorf("no DB cluster snapshot returned for DB cluster %q", sourceID)
+	default:
+		return snapshotOfDBClusterSnapshot(*output.DBClusterSnapshot), nil
+	}
+}
+
+func (clusterSnapshots) waitAvailable(ctx context.Context, client RDSClient, snapshotID string, interval, timeout time.Duration) error {
+	return rds.NewDBClusterSnapshotAvailableWaiter(client, func(o *rds.DBClusterSnapshotAvailableWaiterOptions) {
+		o.MinDelay = interval
+		o.MaxDelay = max(interval, o.MaxDelay)
+	}).Wait(ctx, &rds.DescribeDBClusterSnapshotsInput{DBClusterSnapshotIdentifier: aws.String(snapshotID)}, timeout)
+}
+
+func (clusterSnapshots) share(ctx context.Context, client RDSClient, snapshotID string, accountIDs []string) error {
+	_, err := client.ModifyDBClusterSnapshotAttribute(ctx, &rds.ModifyDBClusterSnapshotAttributeInput{
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+		AttributeName:               aws.String(restoreAttribute),
+		ValuesToAdd:                 accountIDs,
+	})
+	return err
+}
+
+func (clusterSnapshots) copy(ctx context.Context, client RDSClient, source snapshot, sourceRegion, kmsKeyID string) (snapshot, error) {
+	output, err := client.CopyDBClusterSnapshot(ctx, &rds.CopyDBClusterSnapshotInput{
+		SourceDBClusterSnapshotIdentifier: aws.String(source.arn),
+		TargetDBClusterSnapshotIdentifier: aws.String(source.identifier),
+		SourceRegion:                      aws.String(sourceRegion),
+		KmsKeyId:                          optionalString(kmsKeyID),
+		CopyTags:                          aws.Bool(true),
+	})
+	switch {
+	case err != nil:
+		return snapshot{}, err
+	case output == nil || output.DBClusterSnapshot == nil:
+		return snapshot{}, fmt.Errorf("no DB cluster snapshot returned for the copy of %q", source.identifier)
+	default:
+		return snapshotOfDBClusterSnapshot(*output.DBClusterSnapshot), nil
+	}
+}
+
+func (clusterSnapshots) listManual(ctx context.Context, client RDSClient) ([]snapshot, error) {
+	var snapshots []snapshot
+	paginator := rds.NewDescribeDBClusterSnapshotsPaginator(client, &rds.DescribeDBClusterSnapshotsInput{SnapshotType: aws.String("manual")})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range page.DBClusterSnapshots {
+			snapshots = append(snapshots, snapshotOfDBClusterSnapshot(s))
+		}
+	}
+	return snapshots, nil
+}
+
+func (clusterSnapshots) remove(ctx context.Context, client RDSClient, snapshotID string) error {
+	_, err := client.DeleteDBClusterSnapshot(ctx, &rds.DeleteDBClusterSnapshotInput{DBClusterSnapshotIdentifier: aws.String(snapshotID)})
+	var notFound *types.DBClusterSnapshotNotFoundFault
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+func snapshotOfDBSnapshot(s types.DBSnapshot) snapshot {
+	return snapshot{
+		identifier: aws.ToString(s.DBSnapshotIdentifier),
+		arn:        aws.ToString(s.DBSnapshotArn),
+		status:     aws.ToString(s.Status),
+		createdAt:  aws.ToTime(s.SnapshotCreateTime),
+		tags:       tagMap(s.TagList),
+	}
+}
+
+func snapshotOfDBClusterSnapshot(s types.DBClusterSnapshot) snapshot {
+	return snapshot{
+		identifier: aws.ToString(s.DBClusterSnapshotIdentifier),
+		arn:        aws.ToString(s.DBClusterSnapshotArn),
+		status:     aws.ToString(s.Status),
+		createdAt:  aws.ToTime(s.SnapshotCreateTime),
+		tags:       tagMap(s.TagList),
+	}
+}
+
+func tagMap(tags []types.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return m
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
@@ -0,0 +1,24 @@
+# Run "make init" to perform "terraform init"
+
+terraform {
+  required_providers {
+    csbrds = {
+      source  = "cloudfoundry.org/cloud-service-broker/csbrds"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "csbrds" {
+  region = "us-west-2"
+}
+
+resource "csbrds_final_snapshot" "final" {
+  db_instance_identifier = "csb-mysql-46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  instance_id            = "46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  copy_to_region         = "us-east-1"
+  share_with_accounts    = ["123456789012"]
+  retention_count        = 3
+  access_key_id          = "FAKE-access-key-id"
+  secret_access_key      = "FAKE-secret-access-key"
+}
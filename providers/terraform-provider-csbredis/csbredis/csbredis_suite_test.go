@@ -0,0 +1,13 @@
+package csbredis_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCsbredis(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CSB Redis Suite")
+}
@@ -0,0 +1,539 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbredisfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-redis/csbredis"
+)
+
+type FakeElastiCacheClient struct {
+	CreateUserStub        func(context.Context, *elasticache.CreateUserInput, ...func(*elasticache.Options)) (*elasticache.CreateUserOutput, error)
+	createUserMutex       sync.RWMutex
+	createUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.CreateUserInput
+		arg3 []func(*elasticache.Options)
+	}
+	createUserReturns struct {
+		result1 *elasticache.CreateUserOutput
+		result2 error
+	}
+	createUserReturnsOnCall map[int]struct {
+		result1 *elasticache.CreateUserOutput
+		result2 error
+	}
+	DeleteUserStub        func(context.Context, *elasticache.DeleteUserInput, ...func(*elasticache.Options)) (*elasticache.DeleteUserOutput, error)
+	deleteUserMutex       sync.RWMutex
+	deleteUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.DeleteUserInput
+		arg3 []func(*elasticache.Options)
+	}
+	deleteUserReturns struct {
+		result1 *elasticache.DeleteUserOutput
+		result2 error
+	}
+	deleteUserReturnsOnCall map[int]struct {
+		result1 *elasticache.DeleteUserOutput
+		result2 error
+	}
+	DescribeUserGroupsStub        func(context.Context, *elasticache.DescribeUserGroupsInput, ...func(*elasticache.Options)) (*elasticache.DescribeUserGroupsOutput, error)
+	describeUserGroupsMutex       sync.RWMutex
+	describeUserGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.DescribeUserGroupsInput
+		arg3 []func(*elasticache.Options)
+	}
+	describeUserGroupsReturns struct {
+		result1 *elasticache.DescribeUserGroupsOutput
+		result2 error
+	}
+	describeUserGroupsReturnsOnCall map[int]struct {
+		result1 *elasticache.DescribeUserGroupsOutput
+		result2 error
+	}
+	DescribeUsersStub        func(context.Context, *elasticache.DescribeUsersInput, ...func(*elasticache.Options)) (*elasticache.DescribeUsersOutput, error)
+	describeUsersMutex       sync.RWMutex
+	describeUsersArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.DescribeUsersInput
+		arg3 []func(*elasticache.Options)
+	}
+	describeUsersReturns struct {
+		result1 *elasticache.DescribeUsersOutput
+		result2 error
+	}
+	describeUsersReturnsOnCall map[int]struct {
+		result1 *elasticache.DescribeUsersOutput
+		result2 error
+	}
+	ModifyUserStub        func(context.Context, *elasticache.ModifyUserInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserOutput, error)
+	modifyUserMutex       sync.RWMutex
+	modifyUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.ModifyUserInput
+		arg3 []func(*elasticache.Options)
+	}
+	modifyUserReturns struct {
+		result1 *elasticache.ModifyUserOutput
+		result2 error
+	}
+	modifyUserReturnsOnCall map[int]struct {
+		result1 *elasticache.ModifyUserOutput
+		result2 error
+	}
+	ModifyUserGroupStub        func(context.Context, *elasticache.ModifyUserGroupInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserGroupOutput, error)
+	modifyUserGroupMutex       sync.RWMutex
+	modifyUserGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 *elasticache.ModifyUserGroupInput
+		arg3 []func(*elasticache.Options)
+	}
+	modifyUserGroupReturns struct {
+		result1 *elasticache.ModifyUserGroupOutput
+		result2 error
+	}
+	modifyUserGroupReturnsOnCall map[int]struct {
+		result1 *elasticache.ModifyUserGroupOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeElastiCacheClient) CreateUser(arg1 context.Context, arg2 *elasticache.CreateUserInput, arg3 ...func(*elasticache.Options)) (*elasticache.CreateUserOutput, error) {
+	fake.createUserMutex.Lock()
+	ret, specificReturn := fake.createUserReturnsOnCall[len(fake.createUserArgsForCall)]
+	fake.createUserArgsForCall = append(fake.createUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.CreateUserInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateUserStub
+	fakeReturns := fake.createUserReturns
+	fake.recordInvocation("CreateUser", []interface{}{arg1, arg2, arg3})
+	fake.createUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) CreateUserCallCount() int {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	return len(fake.createUserArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) CreateUserCalls(stub func(context.Context, *elasticache.CreateUserInput, ...func(*elasticache.Options)) (*elasticache.CreateUserOutput, error)) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = stub
+}
+
+func (fake *FakeElastiCacheClient) CreateUserArgsForCall(i int) (context.Context, *elasticache.CreateUserInput, []func(*elasticache.Options)) {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	argsForCall := fake.createUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) CreateUserReturns(result1 *elasticache.CreateUserOutput, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = nil
+	fake.createUserReturns = struct {
+		result1 *elasticache.CreateUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) CreateUserReturnsOnCall(i int, result1 *elasticache.CreateUserOutput, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = nil
+	if fake.createUserReturnsOnCall == nil {
+		fake.createUserReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.CreateUserOutput
+			result2 error
+		})
+	}
+	fake.createUserReturnsOnCall[i] = struct {
+		result1 *elasticache.CreateUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DeleteUser(arg1 context.Context, arg2 *elasticache.DeleteUserInput, arg3 ...func(*elasticache.Options)) (*elasticache.DeleteUserOutput, error) {
+	fake.deleteUserMutex.Lock()
+	ret, specificReturn := fake.deleteUserReturnsOnCall[len(fake.deleteUserArgsForCall)]
+	fake.deleteUserArgsForCall = append(fake.deleteUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.DeleteUserInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteUserStub
+	fakeReturns := fake.deleteUserReturns
+	fake.recordInvocation("DeleteUser", []interface{}{arg1, arg2, arg3})
+	fake.deleteUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) DeleteUserCallCount() int {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	return len(fake.deleteUserArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) DeleteUserCalls(stub func(context.Context, *elasticache.DeleteUserInput, ...func(*elasticache.Options)) (*elasticache.DeleteUserOutput, error)) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = stub
+}
+
+func (fake *FakeElastiCacheClient) DeleteUserArgsForCall(i int) (context.Context, *elasticache.DeleteUserInput, []func(*elasticache.Options)) {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	argsForCall := fake.deleteUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) DeleteUserReturns(result1 *elasticache.DeleteUserOutput, result2 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	fake.deleteUserReturns = struct {
+		result1 *elasticache.DeleteUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DeleteUserReturnsOnCall(i int, result1 *elasticache.DeleteUserOutput, result2 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	if fake.deleteUserReturnsOnCall == nil {
+		fake.deleteUserReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.DeleteUserOutput
+			result2 error
+		})
+	}
+	fake.deleteUserReturnsOnCall[i] = struct {
+		result1 *elasticache.DeleteUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroups(arg1 context.Context, arg2 *elasticache.DescribeUserGroupsInput, arg3 ...func(*elasticache.Options)) (*elasticache.DescribeUserGroupsOutput, error) {
+	fake.describeUserGroupsMutex.Lock()
+	ret, specificReturn := fake.describeUserGroupsReturnsOnCall[len(fake.describeUserGroupsArgsForCall)]
+	fake.describeUserGroupsArgsForCall = append(fake.describeUserGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.DescribeUserGroupsInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeUserGroupsStub
+	fakeReturns := fake.describeUserGroupsReturns
+	fake.recordInvocation("DescribeUserGroups", []interface{}{arg1, arg2, arg3})
+	fake.describeUserGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroupsCallCount() int {
+	fake.describeUserGroupsMutex.RLock()
+	defer fake.describeUserGroupsMutex.RUnlock()
+	return len(fake.describeUserGroupsArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroupsCalls(stub func(context.Context, *elasticache.DescribeUserGroupsInput, ...func(*elasticache.Options)) (*elasticache.DescribeUserGroupsOutput, error)) {
+	fake.describeUserGroupsMutex.Lock()
+	defer fake.describeUserGroupsMutex.Unlock()
+	fake.DescribeUserGroupsStub = stub
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroupsArgsForCall(i int) (context.Context, *elasticache.DescribeUserGroupsInput, []func(*elasticache.Options)) {
+	fake.describeUserGroupsMutex.RLock()
+	defer fake.describeUserGroupsMutex.RUnlock()
+	argsForCall := fake.describeUserGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroupsReturns(result1 *elasticache.DescribeUserGroupsOutput, result2 error) {
+	fake.describeUserGroupsMutex.Lock()
+	defer fake.describeUserGroupsMutex.Unlock()
+	fake.DescribeUserGroupsStub = nil
+	fake.describeUserGroupsReturns = struct {
+		result1 *elasticache.DescribeUserGroupsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DescribeUserGroupsReturnsOnCall(i int, result1 *elasticache.DescribeUserGroupsOutput, result2 error) {
+	fake.describeUserGroupsMutex.Lock()
+	defer fake.describeUserGroupsMutex.Unlock()
+	fake.DescribeUserGroupsStub = nil
+	if fake.describeUserGroupsReturnsOnCall == nil {
+		fake.describeUserGroupsReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.DescribeUserGroupsOutput
+			result2 error
+		})
+	}
+	fake.describeUserGroupsReturnsOnCall[i] = struct {
+		result1 *elasticache.DescribeUserGroupsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsers(arg1 context.Context, arg2 *elasticache.DescribeUsersInput, arg3 ...func(*elasticache.Options)) (*elasticache.DescribeUsersOutput, error) {
+	fake.describeUsersMutex.Lock()
+	ret, specificReturn := fake.describeUsersReturnsOnCall[len(fake.describeUsersArgsForCall)]
+	fake.describeUsersArgsForCall = append(fake.describeUsersArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.DescribeUsersInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeUsersStub
+	fakeReturns := fake.describeUsersReturns
+	fake.recordInvocation("DescribeUsers", []interface{}{arg1, arg2, arg3})
+	fake.describeUsersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsersCallCount() int {
+	fake.describeUsersMutex.RLock()
+	defer fake.describeUsersMutex.RUnlock()
+	return len(fake.describeUsersArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsersCalls(stub func(context.Context, *elasticache.DescribeUsersInput, ...func(*elasticache.Options)) (*elasticache.DescribeUsersOutput, error)) {
+	fake.describeUsersMutex.Lock()
+	defer fake.describeUsersMutex.Unlock()
+	fake.DescribeUsersStub = stub
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsersArgsForCall(i int) (context.Context, *elasticache.DescribeUsersInput, []func(*elasticache.Options)) {
+	fake.describeUsersMutex.RLock()
+	defer fake.describeUsersMutex.RUnlock()
+	argsForCall := fake.describeUsersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsersReturns(result1 *elasticache.DescribeUsersOutput, result2 error) {
+	fake.describeUsersMutex.Lock()
+	defer fake.describeUsersMutex.Unlock()
+	fake.DescribeUsersStub = nil
+	fake.describeUsersReturns = struct {
+		result1 *elasticache.DescribeUsersOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) DescribeUsersReturnsOnCall(i int, result1 *elasticache.DescribeUsersOutput, result2 error) {
+	fake.describeUsersMutex.Lock()
+	defer fake.describeUsersMutex.Unlock()
+	fake.DescribeUsersStub = nil
+	if fake.describeUsersReturnsOnCall == nil {
+		fake.describeUsersReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.DescribeUsersOutput
+			result2 error
+		})
+	}
+	fake.describeUsersReturnsOnCall[i] = struct {
+		result1 *elasticache.DescribeUsersOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) ModifyUser(arg1 context.Context, arg2 *elasticache.ModifyUserInput, arg3 ...func(*elasticache.Options)) (*elasticache.ModifyUserOutput, error) {
+	fake.modifyUserMutex.Lock()
+	ret, specificReturn := fake.modifyUserReturnsOnCall[len(fake.modifyUserArgsForCall)]
+	fake.modifyUserArgsForCall = append(fake.modifyUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.ModifyUserInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ModifyUserStub
+	fakeReturns := fake.modifyUserReturns
+	fake.recordInvocation("ModifyUser", []interface{}{arg1, arg2, arg3})
+	fake.modifyUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserCallCount() int {
+	fake.modifyUserMutex.RLock()
+	defer fake.modifyUserMutex.RUnlock()
+	return len(fake.modifyUserArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserCalls(stub func(context.Context, *elasticache.ModifyUserInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserOutput, error)) {
+	fake.modifyUserMutex.Lock()
+	defer fake.modifyUserMutex.Unlock()
+	fake.ModifyUserStub = stub
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserArgsForCall(i int) (context.Context, *elasticache.ModifyUserInput, []func(*elasticache.Options)) {
+	fake.modifyUserMutex.RLock()
+	defer fake.modifyUserMutex.RUnlock()
+	argsForCall := fake.modifyUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserReturns(result1 *elasticache.ModifyUserOutput, result2 error) {
+	fake.modifyUserMutex.Lock()
+	defer fake.modifyUserMutex.Unlock()
+	fake.ModifyUserStub = nil
+	fake.modifyUserReturns = struct {
+		result1 *elasticache.ModifyUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserReturnsOnCall(i int, result1 *elasticache.ModifyUserOutput, result2 error) {
+	fake.modifyUserMutex.Lock()
+	defer fake.modifyUserMutex.Unlock()
+	fake.ModifyUserStub = nil
+	if fake.modifyUserReturnsOnCall == nil {
+		fake.modifyUserReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.ModifyUserOutput
+			result2 error
+		})
+	}
+	fake.modifyUserReturnsOnCall[i] = struct {
+		result1 *elasticache.ModifyUserOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroup(arg1 context.Context, arg2 *elasticache.ModifyUserGroupInput, arg3 ...func(*elasticache.Options)) (*elasticache.ModifyUserGroupOutput, error) {
+	fake.modifyUserGroupMutex.Lock()
+	ret, specificReturn := fake.modifyUserGroupReturnsOnCall[len(fake.modifyUserGroupArgsForCall)]
+	fake.modifyUserGroupArgsForCall = append(fake.modifyUserGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 *elasticache.ModifyUserGroupInput
+		arg3 []func(*elasticache.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ModifyUserGroupStub
+	fakeReturns := fake.modifyUserGroupReturns
+	fake.recordInvocation("ModifyUserGroup", []interface{}{arg1, arg2, arg3})
+	fake.modifyUserGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroupCallCount() int {
+	fake.modifyUserGroupMutex.RLock()
+	defer fake.modifyUserGroupMutex.RUnlock()
+	return len(fake.modifyUserGroupArgsForCall)
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroupCalls(stub func(context.Context, *elasticache.ModifyUserGroupInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserGroupOutput, error)) {
+	fake.modifyUserGroupMutex.Lock()
+	defer fake.modifyUserGroupMutex.Unlock()
+	fake.ModifyUserGroupStub = stub
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroupArgsForCall(i int) (context.Context, *elasticache.ModifyUserGroupInput, []func(*elasticache.Options)) {
+	fake.modifyUserGroupMutex.RLock()
+	defer fake.modifyUserGroupMutex.RUnlock()
+	argsForCall := fake.modifyUserGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroupReturns(result1 *elasticache.ModifyUserGroupOutput, result2 error) {
+	fake.modifyUserGroupMutex.Lock()
+	defer fake.modifyUserGroupMutex.Unlock()
+	fake.ModifyUserGroupStub = nil
+	fake.modifyUserGroupReturns = struct {
+		result1 *elasticache.ModifyUserGroupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) ModifyUserGroupReturnsOnCall(i int, result1 *elasticache.ModifyUserGroupOutput, result2 error) {
+	fake.modifyUserGroupMutex.Lock()
+	defer fake.modifyUserGroupMutex.Unlock()
+	fake.ModifyUserGroupStub = nil
+	if fake.modifyUserGroupReturnsOnCall == nil {
+		fake.modifyUserGroupReturnsOnCall = make(map[int]struct {
+			result1 *elasticache.ModifyUserGroupOutput
+			result2 error
+		})
+	}
+	fake.modifyUserGroupReturnsOnCall[i] = struct {
+		result1 *elasticache.ModifyUserGroupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeElastiCacheClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	fake.describeUserGroupsMutex.RLock()
+	defer fake.describeUserGroupsMutex.RUnlock()
+	fake.describeUsersMutex.RLock()
+	defer fake.describeUsersMutex.RUnlock()
+	fake.modifyUserMutex.RLock()
+	defer fake.modifyUserMutex.RUnlock()
+	fake.modifyUserGroupMutex.RLock()
+	defer fake.modifyUserGroupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeElastiCacheClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbredis.ElastiCacheClient = new(FakeElastiCacheClient)
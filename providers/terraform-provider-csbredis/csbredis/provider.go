@@ -0,0 +1,57 @@
+// Package csbredis is a Terraform provider specialised for the Redis service of the AWS brokerpak
+package csbredis
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	awsRegionKey         = "region"
+	customEndpointURLKey = "custom_endpoint_url"
+)
+
+var regionRegexp = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			awsRegionKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			customEndpointURLKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Endpoint of the ElastiCache API, for local stand-ins such as LocalStack",
+			},
+		},
+		ConfigureContextFunc: ProviderConfigure,
+		ResourcesMap: map[string]*schema.Resource{
+			"csbredis_binding_user": ResourceBindingUser(),
+		},
+	}
+}
+
+func ProviderConfigure(_ context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+	// We rely on Terraform to supply the correct types, and it's ok panic if this contract is broken
+	region := d.Get(awsRegionKey).(string)
+	if !regionRegexp.MatchString(region) {
+		return nil, diag.Errorf("invalid value %q for %q, validation expression is: %s", region, awsRegionKey, regionRegexp.String())
+	}
+
+	var customEndpointURL string
+	if customURL, ok := d.GetOk(customEndpointURLKey); ok {
+		uri, err := url.ParseRequestURI(customURL.(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		customEndpointURL = uri.String()
+	}
+
+	return &elastiCacheSettings{region: region, customEndpointURL: customEndpointURL}, nil
+}
@@ -0,0 +1,128 @@
+package csbredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+)
+
+// statusActive is the status of users and user groups that are not changing
+const statusActive = "active"
+
+func describeUser(ctx context.Context, client ElastiCacheClient, username string) (types.User, error) {
+	output, err := client.DescribeUsers(ctx, &elasticache.DescribeUsersInput{UserId: aws.String(username)})
+	switch {
+	case err != nil:
+		return types.User{}, err
+	case output == nil || len(output.Users) == 0:
+		return types.User{}, &types.UserNotFoundFault{Message: aws.String(fmt.Sprintf("user %q not found", username))}
+	default:
+		return output.Users[0], nil
+	}
+}
+
+func describeUserGroup(ctx context.Context, client ElastiCacheClient, userGroupID string) (types.UserGroup, error) {
+	output, err := client.DescribeUserGroups(ctx, &elasticache.DescribeUserGroupsInput{UserGroupId: aws.String(userGroupID)})
+	switch {
+	case err != nil:
+		return types.UserGroup{}, err
+	case output == nil || len(output.UserGroups) == 0:
+		return types.UserGroup{}, &types.UserGroupNotFoundFault{Message: aws.String(fmt.Sprintf("user group %q not found", userGroupID))}
+	default:
+		return output.UserGroups[0], nil
+	}
+}
+
+func waitForUser(ctx context.Context, client ElastiCacheClient, username string, interval time.Duration) error {
+	err := poll(ctx, interval, func() (bool, error) {
+		user, err := describeUser(ctx, client, username)
+		return aws.ToString(user.Status) == statusActive, err
+	})
+	if err != nil {
+		return fmt.Errorf("user %q did not become active: %w", username, err)
+	}
+	return nil
+}
+
+func waitForUserDeletion(ctx context.Context, client ElastiCacheClient, username string, interval time.Duration) error {
+	err := poll(ctx, interval, func() (bool, error) {
+		_, err := describeUser(ctx, client, username)
+		var notFound *types.UserNotFoundFault
+		if errors.As(err, &notFound) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("user %q did not finish deleting: %w", username, err)
+	}
+	return nil
+}
+
+// changeUserGroup adds or removes users, and waits until the change has been applied to the replication groups
+// of the user group. Bindings of the same instance change the user group concurrently, and a user group rejects
+// changes while it is modifying, so the change is retried until the user group accepts it.
+func changeUserGroup(ctx context.Context, client ElastiCacheClient, input *elasticache.ModifyUserGroupInput, interval time.Duration) error {
+	userGroupID := aws.ToString(input.UserGroupId)
+	if err := retryWhileChanging(ctx, interval, func() error {
+		_, err := client.ModifyUserGroup(ctx, input)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	err := poll(ctx, interval, func() (bool, error) {
+		userGroup, err := describeUserGroup(ctx, client, userGroupID)
+		return aws.ToString(userGroup.Status) == statusActive, err
+	})
+	if err != nil {
+		return fmt.Errorf("user group %q did not become active: %w", userGroupID, err)
+	}
+	return nil
+}
+
+// retryWhileChanging retries an operation that was rejected because the user or user group is changing
+func retryWhileChanging(ctx context.Context, interval time.Duration, operation func() error) error {
+	var lastErr error
+	err := poll(ctx, interval, func() (bool, error) {
+		lastErr = operation()
+
+		var (
+			invalidUserState      *types.InvalidUserStateFault
+			invalidUserGroupState *types.InvalidUserGroupStateFault
+		)
+		if errors.As(lastErr, &invalidUserState) || errors.As(lastErr, &invalidUserGroupState) {
+			return false, nil
+		}
+		return true, lastErr
+	})
+	if err != nil && lastErr != nil && !errors.Is(err, lastErr) {
+		// Report why the operation never succeeded, rather than only that the time ran out
+		return fmt.Errorf("%w: %w", err, lastErr)
+	}
+	return err
+}
+
+// poll calls check every interval until it is done or fails, or the context is done
+func poll(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		switch {
+		case err != nil:
+			return err
+		case done:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package csbredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	UserGroupIDKey  = "user_group_id"
+	UsernameKey     = "username"
+	PasswordKey     = "password"
+	AccessStringKey = "access_string"
+	EngineKey       = "engine"
+	PollIntervalKey = "poll_interval"
+
+	defaultAccessString = "on ~* +@all"
+	defaultEngine       = "redis"
+	defaultPollInterval = 10 * time.Second
+	defaultTimeout      = 15 * time.Minute
+)
+
+var (
+	// ElastiCache user IDs start with a letter, and only have letters, digits and single hyphens
+	usernameRegexp        = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{0,39}$`)
+	invalidHyphensRegexp  = regexp.MustCompile(`--|-$`)
+	supportedEngineValues = []string{"redis", "valkey"}
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbredisfakes/header.txt . ElastiCacheClient
+type ElastiCacheClient interface {
+	CreateUser(context.Context, *elasticache.CreateUserInput, ...func(*elasticache.Options)) (*elasticache.CreateUserOutput, error)
+	elasticache.DescribeUsersAPIClient
+	ModifyUser(context.Context, *elasticache.ModifyUserInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserOutput, error)
+	DeleteUser(context.Context, *elasticache.DeleteUserInput, ...func(*elasticache.Options)) (*elasticache.DeleteUserOutput, error)
+	ModifyUserGroup(context.Context, *elasticache.ModifyUserGroupInput, ...func(*elasticache.Options)) (*elasticache.ModifyUserGroupOutput, error)
+	elasticache.DescribeUserGroupsAPIClient
+}
+
+var _ ElastiCacheClient = &elasticache.Client{}
+
+func ResourceBindingUser() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			UserGroupIDKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "ID of the user group of the replication group that the user is attached to",
+			},
+			UsernameKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringMatch(usernameRegexp, "must start with a letter, and only have up to 40 letters, digits and hyphens"),
+					validation.StringDoesNotMatch(invalidHyphensRegexp, "must not have two consecutive hyphens or end with a hyphen"),
+				),
+				Description: "Name of the user, which is also its user ID",
+			},
+			PasswordKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(16, 128),
+				Description:  "Password of the user",
+			},
+			AccessStringKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultAccessString,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Access control list of the user, in the Redis ACL syntax",
+			},
+			EngineKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultEngine,
+				ValidateFunc: validation.StringInSlice(supportedEngineValues, false),
+				Description:  "Engine of the user group",
+			},
+			PollIntervalKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultPollInterval.String(),
+				ValidateFunc: validatePollInterval,
+				Description:  "Interval between checks that the user and the user group have finished changing",
+			},
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		CreateContext: ResourceBindingUserCreate,
+		ReadContext:   ResourceBindingUserRead,
+		UpdateContext: ResourceBindingUserUpdate,
+		DeleteContext: ResourceBindingUserDelete,
+		Description:   "ElastiCache RBAC user of a binding, attached to the user group of the replication group",
+	}
+}
+
+func ResourceBindingUserCreate(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(ElastiCacheConfig).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	username := data.Get(UsernameKey).(string)
+	if _, err := client.CreateUser(ctx, &elasticache.CreateUserInput{
+		UserId:       aws.String(username),
+		UserName:     aws.String(username),
+		Engine:       aws.String(data.Get(EngineKey).(string)),
+		AccessString: aws.String(data.Get(AccessStringKey).(string)),
+		AuthenticationMode: &types.AuthenticationMode{
+			Type:      types.InputAuthenticationTypePassword,
+			Passwords: []string{data.Get(PasswordKey).(string)},
+		},
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The user exists from now on, so a failure must leave it in the state, where it is tainted and then deleted
+	data.SetId(username)
+
+	interval := pollInterval(data)
+	if err := waitForUser(ctx, client, username, interval); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := changeUserGroup(ctx, client, &elasticache.ModifyUserGroupInput{
+		UserGroupId:  aws.String(data.Get(UserGroupIDKey).(string)),
+		UserIdsToAdd: []string{username},
+	}, interval); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// ResourceBindingUserRead removes the resource from the state when the user no longer exists, so that it is created again
+func ResourceBindingUserRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(ElastiCacheConfig).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	user, err := describeUser(ctx, client, data.Id())
+	var notFound *types.UserNotFoundFault
+	switch {
+	case errors.As(err, &notFound):
+		tflog.Warn(ctx, "Removing binding user from the state because it no longer exists", map[string]any{
+			"username": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	if userGroupID := data.Get(UserGroupIDKey).(string); !slices.Contains(user.UserGroupIds, userGroupID) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("user %q is no longer attached to user group %q", data.Id(), userGroupID),
+			Detail:   "the binding cannot connect to the replication group until the user is attached to its user group again",
+		}}
+	}
+	return nil
+}
+
+// ResourceBindingUserUpdate changes the password or access string of the user in place, so that the binding keeps its user
+func ResourceBindingUserUpdate(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	if !data.HasChanges(PasswordKey, AccessStringKey) {
+		return nil
+	}
+
+	client, err := config.(ElastiCacheConfig).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &elasticache.ModifyUserInput{UserId: aws.String(data.Id())}
+	if data.HasChange(AccessStringKey) {
+		input.AccessString = aws.String(data.Get(AccessStringKey).(string))
+	}
+	if data.HasChange(PasswordKey) {
+		// Replaces the password, rather than adding a second one
+		input.AuthenticationMode = &types.AuthenticationMode{
+			Type:      types.InputAuthenticationTypePassword,
+			Passwords: []string{data.Get(PasswordKey).(string)},
+		}
+	}
+
+	interval := pollInterval(data)
+	if err := retryWhileChanging(ctx, interval, func() error {
+		_, err := client.ModifyUser(ctx, input)
+		return err
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForUser(ctx, client, data.Id(), interval); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// ResourceBindingUserDelete detaches the user from the user group and deletes it, which revokes the access of the binding only
+func ResourceBindingUserDelete(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(ElastiCacheConfig).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	username := data.Id()
+	user, err := describeUser(ctx, client, username)
+	var userNotFound *types.UserNotFoundFault
+	switch {
+	case errors.As(err, &userNotFound):
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	interval := pollInterval(data)
+	userGroupID := data.Get(UserGroupIDKey).(string)
+	if slices.Contains(user.UserGroupIds, userGroupID) {
+		err := changeUserGroup(ctx, client, &elasticache.ModifyUserGroupInput{
+			UserGroupId:     aws.String(userGroupID),
+			UserIdsToRemove: []string{username},
+		}, interval)
+		var groupNotFound *types.UserGroupNotFoundFault
+		if err != nil && !errors.As(err, &groupNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = retryWhileChanging(ctx, interval, func() error {
+		_, err := client.DeleteUser(ctx, &elasticache.DeleteUserInput{UserId: aws.String(username)})
+		return err
+	})
+	switch {
+	case errors.As(err, &userNotFound):
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	}
+
+	if err := waitForUserDeletion(ctx, client, username, interval); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// pollInterval is the wait between checks of the status of the user and the user group, while ElastiCache
+// applies a change. poll_interval has a default, so it is never empty.
+func pollInterval(data *schema.ResourceData) time.Duration {
+	interval, _ := time.ParseDuration(data.Get(PollIntervalKey).(string))
+	return interval
+}
+
+func validatePollInterval(i any, key string) (warnings []string, errs []error) {
+	interval, err := time.ParseDuration(i.(string))
+	switch {
+	case err != nil:
+		errs = append(errs, fmt.Errorf("%q is not a duration: %w", key, err))
+	case interval <= 0:
+		errs = append(errs, fmt.Errorf("%q must be positive, got %s", key, interval))
+	}
+	return warnings, errs
+}
@@ -0,0 +1,300 @@
+package csbredis_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-redis/csbredis"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-redis/csbredis/csbredisfakes"
+)
+
+const (
+	username    = "csb-binding-user"
+	userGroupID = "csb-user-group"
+	password    = "0123456789abcdef"
+)
+
+var _ = Describe("ResourceBindingUser", func() {
+	var (
+		client *csbredisfakes.FakeElastiCacheClient
+		config *csbredisfakes.FakeElastiCacheConfig
+		data   *schema.ResourceData
+	)
+
+	raw := func(overrides map[string]any) map[string]any {
+		values := map[string]any{
+			csbredis.UserGroupIDKey:        userGroupID,
+			csbredis.UsernameKey:           username,
+			csbredis.PasswordKey:           password,
+			csbredis.PollIntervalKey:       "1ms",
+			csbredis.AwsAccessKeyIDKey:     "id",
+			csbredis.AwsSecretAccessKeyKey: "key",
+		}
+		for key, value := range overrides {
+			values[key] = value
+		}
+		return values
+	}
+
+	// changedData returns the data of an existing user whose configuration changes to the overrides
+	changedData := func(overrides map[string]any) *schema.ResourceData {
+		resource := csbredis.ResourceBindingUser()
+		existing := schema.TestResourceDataRaw(GinkgoT(), resource.Schema, raw(nil))
+		existing.SetId(username)
+		state := existing.State()
+
+		diff, err := resource.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(raw(overrides)), nil)
+		Expect(err).NotTo(HaveOccurred())
+		changed, err := schema.InternalMap(resource.Schema).Data(state, diff)
+		Expect(err).NotTo(HaveOccurred())
+		return changed
+	}
+
+	user := func(status string, userGroupIDs ...string) *elasticache.DescribeUsersOutput {
+		return &elasticache.DescribeUsersOutput{Users: []types.User{{
+			UserId:       aws.String(username),
+			Status:       aws.String(status),
+			UserGroupIds: userGroupIDs,
+		}}}
+	}
+
+	userGroup := func(status string) *elasticache.DescribeUserGroupsOutput {
+		return &elasticache.DescribeUserGroupsOutput{UserGroups: []types.UserGroup{{
+			UserGroupId: aws.String(userGroupID),
+			Status:      aws.String(status),
+		}}}
+	}
+
+	BeforeEach(func() {
+		client = &csbredisfakes.FakeElastiCacheClient{}
+		client.CreateUserReturns(&elasticache.CreateUserOutput{}, nil)
+		client.DescribeUsersReturns(user("active", userGroupID), nil)
+		client.ModifyUserReturns(&elasticache.ModifyUserOutput{}, nil)
+		client.DeleteUserReturns(&elasticache.DeleteUserOutput{}, nil)
+		client.ModifyUserGroupReturns(&elasticache.ModifyUserGroupOutput{}, nil)
+		client.DescribeUserGroupsReturns(userGroup("active"), nil)
+
+		config = &csbredisfakes.FakeElastiCacheConfig{}
+		config.GetClientReturns(client, nil)
+
+		data = schema.TestResourceDataRaw(GinkgoT(), csbredis.ResourceBindingUser().Schema, raw(nil))
+	})
+
+	Describe("create", func() {
+		It("creates the user and attaches it to the user group", func() {
+			client.DescribeUsersReturnsOnCall(0, user("creating"), nil)
+			client.DescribeUserGroupsReturnsOnCall(0, userGroup("modifying"), nil)
+
+			Expect(csbredis.ResourceBindingUserCreate(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(username))
+
+			Expect(client.CreateUserCallCount()).To(Equal(1))
+			_, input, _ := client.CreateUserArgsForCall(0)
+			Expect(aws.ToString(input.UserId)).To(Equal(username))
+			Expect(aws.ToString(input.UserName)).To(Equal(username))
+			Expect(aws.ToString(input.Engine)).To(Equal("redis"))
+			Expect(aws.ToString(input.AccessString)).To(Equal("on ~* +@all"))
+			Expect(input.AuthenticationMode).To(Equal(&types.AuthenticationMode{
+				Type:      types.InputAuthenticationTypePassword,
+				Passwords: []string{password},
+			}))
+
+			Expect(client.DescribeUsersCallCount()).To(Equal(2))
+			Expect(client.ModifyUserGroupCallCount()).To(Equal(1))
+			_, groupInput, _ := client.ModifyUserGroupArgsForCall(0)
+			Expect(aws.ToString(groupInput.UserGroupId)).To(Equal(userGroupID))
+			Expect(groupInput.UserIdsToAdd).To(ConsistOf(username))
+			Expect(client.DescribeUserGroupsCallCount()).To(Equal(2))
+		})
+
+		It("uses the access string and engine of the binding", func() {
+			data = schema.TestResourceDataRaw(GinkgoT(), csbredis.ResourceBindingUser().Schema, raw(map[string]any{
+				csbredis.AccessStringKey: "on ~app:* +@read",
+				csbredis.EngineKey:       "valkey",
+			}))
+
+			Expect(csbredis.ResourceBindingUserCreate(context.TODO(), data, config)).To(BeNil())
+			_, input, _ := client.CreateUserArgsForCall(0)
+			Expect(aws.ToString(input.AccessString)).To(Equal("on ~app:* +@read"))
+			Expect(aws.ToString(input.Engine)).To(Equal("valkey"))
+		})
+
+		It("retries while another binding is changing the user group", func() {
+			client.ModifyUserGroupReturnsOnCall(0, nil, &types.InvalidUserGroupStateFault{Message: aws.String("modifying")})
+			client.ModifyUserGroupReturnsOnCall(1, nil, &types.InvalidUserGroupStateFault{Message: aws.String("modifying")})
+
+			Expect(csbredis.ResourceBindingUserCreate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.ModifyUserGroupCallCount()).To(Equal(3))
+		})
+
+		It("keeps the user in the state when it cannot be attached", func() {
+			client.ModifyUserGroupReturns(nil, &types.UserGroupNotFoundFault{Message: aws.String("not found")})
+
+			d := csbredis.ResourceBindingUserCreate(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Error))
+			Expect(d[0].Summary).To(ContainSubstring("not found"))
+			Expect(data.Id()).To(Equal(username))
+		})
+
+		It("fails without state when the user cannot be created", func() {
+			client.CreateUserReturns(nil, &types.UserAlreadyExistsFault{Message: aws.String("already exists")})
+
+			d := csbredis.ResourceBindingUserCreate(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Summary).To(ContainSubstring("already exists"))
+			Expect(data.Id()).To(BeEmpty())
+			Expect(client.ModifyUserGroupCallCount()).To(BeZero())
+		})
+
+		It("reports why the user group was never changed when the timeout is reached", func() {
+			client.ModifyUserGroupReturns(nil, &types.InvalidUserGroupStateFault{Message: aws.String("still modifying")})
+			ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+			defer cancel()
+
+			d := csbredis.ResourceBindingUserCreate(ctx, data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Summary).To(ContainSubstring(context.DeadlineExceeded.Error()))
+			Expect(d[0].Summary).To(ContainSubstring("still modifying"))
+		})
+	})
+
+	Describe("read", func() {
+		BeforeEach(func() {
+			data.SetId(username)
+		})
+
+		It("keeps a user that is attached to the user group", func() {
+			Expect(csbredis.ResourceBindingUserRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(username))
+
+			_, input, _ := client.DescribeUsersArgsForCall(0)
+			Expect(aws.ToString(input.UserId)).To(Equal(username))
+		})
+
+		It("removes the resource from the state when the user no longer exists", func() {
+			client.DescribeUsersReturns(nil, &types.UserNotFoundFault{Message: aws.String("not found")})
+
+			Expect(csbredis.ResourceBindingUserRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(BeEmpty())
+		})
+
+		It("warns when the user is no longer attached to the user group", func() {
+			client.DescribeUsersReturns(user("active", "other-group"), nil)
+
+			d := csbredis.ResourceBindingUserRead(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Warning))
+			Expect(d[0].Summary).To(Equal(`user "csb-binding-user" is no longer attached to user group "csb-user-group"`))
+		})
+	})
+
+	Describe("update", func() {
+		It("replaces the password of the user", func() {
+			data = changedData(map[string]any{csbredis.PasswordKey: "fedcba9876543210"})
+			client.DescribeUsersReturnsOnCall(0, user("modifying", userGroupID), nil)
+
+			Expect(csbredis.ResourceBindingUserUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.ModifyUserCallCount()).To(Equal(1))
+			_, input, _ := client.ModifyUserArgsForCall(0)
+			Expect(aws.ToString(input.UserId)).To(Equal(username))
+			Expect(input.AccessString).To(BeNil())
+			Expect(input.AuthenticationMode).To(Equal(&types.AuthenticationMode{
+				Type:      types.InputAuthenticationTypePassword,
+				Passwords: []string{"fedcba9876543210"},
+			}))
+			Expect(client.DescribeUsersCallCount()).To(Equal(2))
+		})
+
+		It("changes the access string of the user", func() {
+			data = changedData(map[string]any{csbredis.AccessStringKey: "on ~* +@read"})
+
+			Expect(csbredis.ResourceBindingUserUpdate(context.TODO(), data, config)).To(BeNil())
+			_, input, _ := client.ModifyUserArgsForCall(0)
+			Expect(aws.ToString(input.AccessString)).To(Equal("on ~* +@read"))
+			Expect(input.AuthenticationMode).To(BeNil())
+		})
+
+		It("does not change the user when only the poll interval changes", func() {
+			data = changedData(map[string]any{csbredis.PollIntervalKey: "2ms"})
+
+			Expect(csbredis.ResourceBindingUserUpdate(context.TODO(), data, config)).To(BeNil())
+			Expect(client.ModifyUserCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("delete", func() {
+		BeforeEach(func() {
+			data.SetId(username)
+			client.DescribeUsersReturnsOnCall(2, nil, &types.UserNotFoundFault{Message: aws.String("not found")})
+		})
+
+		It("detaches the user from the user group and deletes it", func() {
+			client.DescribeUsersReturnsOnCall(1, user("deleting"), nil)
+
+			Expect(csbredis.ResourceBindingUserDelete(context.TODO(), data, config)).To(BeNil())
+
+			Expect(client.ModifyUserGroupCallCount()).To(Equal(1))
+			_, groupInput, _ := client.ModifyUserGroupArgsForCall(0)
+			Expect(aws.ToString(groupInput.UserGroupId)).To(Equal(userGroupID))
+			Expect(groupInput.UserIdsToRemove).To(ConsistOf(username))
+			Expect(groupInput.UserIdsToAdd).To(BeEmpty())
+
+			Expect(client.DeleteUserCallCount()).To(Equal(1))
+			_, input, _ := client.DeleteUserArgsForCall(0)
+			Expect(aws.ToString(input.UserId)).To(Equal(username))
+			Expect(client.DescribeUsersCallCount()).To(Equal(3))
+		})
+
+		It("does not change the user group when the user is not attached to it", func() {
+			client.DescribeUsersReturnsOnCall(0, user("active"), nil)
+			client.DescribeUsersReturnsOnCall(1, nil, &types.UserNotFoundFault{Message: aws.String("not found")})
+
+			Expect(csbredis.ResourceBindingUserDelete(context.TODO(), data, config)).To(BeNil())
+			Expect(client.ModifyUserGroupCallCount()).To(BeZero())
+			Expect(client.DeleteUserCallCount()).To(Equal(1))
+		})
+
+		It("deletes the user when the user group no longer exists", func() {
+			client.ModifyUserGroupReturns(nil, &types.UserGroupNotFoundFault{Message: aws.String("not found")})
+			client.DescribeUsersReturnsOnCall(1, nil, &types.UserNotFoundFault{Message: aws.String("not found")})
+
+			Expect(csbredis.ResourceBindingUserDelete(context.TODO(), data, config)).To(BeNil())
+			Expect(client.DeleteUserCallCount()).To(Equal(1))
+		})
+
+		It("retries while the user is changing", func() {
+			client.DescribeUsersReturnsOnCall(1, nil, &types.UserNotFoundFault{Message: aws.String("not found")})
+			client.DeleteUserReturnsOnCall(0, nil, &types.InvalidUserStateFault{Message: aws.String("modifying")})
+
+			Expect(csbredis.ResourceBindingUserDelete(context.TODO(), data, config)).To(BeNil())
+			Expect(client.DeleteUserCallCount()).To(Equal(2))
+		})
+
+		It("succeeds when the user no longer exists", func() {
+			client.DescribeUsersReturnsOnCall(0, &elasticache.DescribeUsersOutput{}, nil)
+
+			Expect(csbredis.ResourceBindingUserDelete(context.TODO(), data, config)).To(BeNil())
+			Expect(client.ModifyUserGroupCallCount()).To(BeZero())
+			Expect(client.DeleteUserCallCount()).To(BeZero())
+		})
+
+		It("fails when the user cannot be deleted", func() {
+			client.DeleteUserReturns(nil, errors.New("access denied"))
+
+			d := csbredis.ResourceBindingUserDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Summary).To(Equal("access denied"))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package csbredis
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+)
+
+//counterfeiter:generate -header csbredisfakes/header.txt . ElastiCacheConfig
+type ElastiCacheConfig interface {
+	GetClient(ctx context.Context, creds Credentials) (ElastiCacheClient, error)
+}
+
+type elastiCacheSettings struct {
+	region            string
+	customEndpointURL string
+}
+
+// Fail fast if the interface is not implemented
+var _ ElastiCacheConfig = &elastiCacheSettings{}
+
+func (e *elastiCacheSettings) GetClient(ctx context.Context, creds Credentials) (ElastiCacheClient, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(e.region),
+		config.WithCredentialsProvider(
+			aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(
+					creds.AccessKeyID,
+					creds.SecretAccessKey,
+					creds.SessionToken,
+				),
+			),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return elasticache.NewFromConfig(cfg, func(o *elasticache.Options) {
+		if e.customEndpointURL != "" {
+			o.BaseEndpoint = aws.String(e.customEndpointURL)
+		}
+	}), nil
+}
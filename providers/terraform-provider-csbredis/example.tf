@@ -0,0 +1,22 @@
+# Run "make init" to perform "terraform init"
+
+terraform {
+  required_providers {
+    csbredis = {
+      source  = "cloudfoundry.org/cloud-service-broker/csbredis"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "csbredis" {
+  region = "us-west-2"
+}
+
+resource "csbredis_binding_user" "binding_user" {
+  user_group_id     = "csb-redis-46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  username          = "csb-0f5b3c6e-3e9a-4a0e-9a47-1c7b5b1f2d11"
+  password          = "FAKE-password-0123456789"
+  access_key_id     = "FAKE-access-key-id"
+  secret_access_key = "FAKE-secret-access-key"
+}
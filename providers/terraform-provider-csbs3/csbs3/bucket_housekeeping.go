@@ -0,0 +1,104 @@
+package csbs3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const accessDeniedCode = "AccessDenied"
+
+// emptyBucket deletes every object version and delete marker in the bucket, one page of the listing at a time.
+// Objects that cannot be deleted are reported as errors, and the remaining pages are still processed, so that
+// a single locked object does not prevent the rest of the bucket from being emptied.
+func emptyBucket(ctx context.Context, client S3Client, bucket string, bypassGovernance bool) (d diag.Diagnostics) {
+	var keyMarker, versionIDMarker *string
+	for {
+		page, err := client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+			Bucket:          aws.String(bucket),
+			KeyMarker:       keyMarker,
+			VersionIdMarker: versionIDMarker,
+		})
+		var noSuchBucket *types.NoSuchBucket
+		switch {
+		case errors.As(err, &noSuchBucket):
+			// The bucket is already gone
+			return d
+		case err != nil:
+			// We have to return immediately in order to avoid an infinite loop
+			return append(d, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
+		}
+
+		if objects := objectIdentifiers(page); len(objects) > 0 {
+			d = append(d, deleteObjects(ctx, client, bucket, objects, bypassGovernance)...)
+		}
+
+		// Stop on repeated markers in order to avoid an infinite loop
+		if !aws.ToBool(page.IsTruncated) ||
+			(aws.ToString(page.NextKeyMarker) == aws.ToString(keyMarker) && aws.ToString(page.NextVersionIdMarker) == aws.ToString(versionIDMarker)) {
+			return d
+		}
+		keyMarker, versionIDMarker = page.NextKeyMarker, page.NextVersionIdMarker
+	}
+}
+
+// objectIdentifiers returns the object versions and delete markers of a page. A page has at most 1000 entries,
+// which is also the maximum number of objects that a single DeleteObjects request accepts.
+func objectIdentifiers(page *s3.ListObjectVersionsOutput) []types.ObjectIdentifier {
+	var objects []types.ObjectIdentifier
+	for _, version := range page.Versions {
+		objects = append(objects, types.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+	}
+	for _, marker := range page.DeleteMarkers {
+		objects = append(objects, types.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+	}
+	return objects
+}
+
+func deleteObjects(ctx context.Context, client S3Client, bucket string, objects []types.ObjectIdentifier, bypassGovernance bool) (d diag.Diagnostics) {
+	input := &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+	}
+	if bypassGovernance {
+		input.BypassGovernanceRetention = aws.Bool(true)
+	}
+
+	tflog.Debug(ctx, "Deleting S3 object versions", map[string]any{"bucket": bucket, "count": len(objects)})
+	output, err := client.DeleteObjects(ctx, input)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   fmt.Sprintf("%d object versions of bucket %q were not deleted", len(objects), bucket),
+		}}
+	}
+
+	// In quiet mode only the objects that could not be deleted are returned
+	for _, objectErr := range output.Errors {
+		d = append(d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary: fmt.Sprintf("object %q version %q was not deleted: %s",
+				aws.ToString(objectErr.Key), aws.ToString(objectErr.VersionId), aws.ToString(objectErr.Message)),
+			Detail: objectErrorDetail(aws.ToString(objectErr.Code), bypassGovernance),
+		})
+	}
+	return d
+}
+
+func objectErrorDetail(code string, bypassGovernance bool) string {
+	switch {
+	case code != accessDeniedCode:
+		return code
+	case bypassGovernance:
+		return "the object version may be under compliance mode retention or a legal hold, which must expire or be removed before it can be deleted"
+	default:
+		return "the object version may be under Object Lock retention: governance mode retention is only bypassed when bypass_governance_retention is set"
+	}
+}
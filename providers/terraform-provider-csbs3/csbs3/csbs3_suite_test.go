@@ -0,0 +1,13 @@
+package csbs3_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCsbs3(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CSB S3 Suite")
+}
@@ -0,0 +1,290 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbs3fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-s3/csbs3"
+)
+
+type FakeS3Client struct {
+	DeleteObjectsStub        func(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	deleteObjectsMutex       sync.RWMutex
+	deleteObjectsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *s3.DeleteObjectsInput
+		arg3 []func(*s3.Options)
+	}
+	deleteObjectsReturns struct {
+		result1 *s3.DeleteObjectsOutput
+		result2 error
+	}
+	deleteObjectsReturnsOnCall map[int]struct {
+		result1 *s3.DeleteObjectsOutput
+		result2 error
+	}
+	HeadBucketStub        func(context.Context, *s3.HeadBucketInput, ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	headBucketMutex       sync.RWMutex
+	headBucketArgsForCall []struct {
+		arg1 context.Context
+		arg2 *s3.HeadBucketInput
+		arg3 []func(*s3.Options)
+	}
+	headBucketReturns struct {
+		result1 *s3.HeadBucketOutput
+		result2 error
+	}
+	headBucketReturnsOnCall map[int]struct {
+		result1 *s3.HeadBucketOutput
+		result2 error
+	}
+	ListObjectVersionsStub        func(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	listObjectVersionsMutex       sync.RWMutex
+	listObjectVersionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *s3.ListObjectVersionsInput
+		arg3 []func(*s3.Options)
+	}
+	listObjectVersionsReturns struct {
+		result1 *s3.ListObjectVersionsOutput
+		result2 error
+	}
+	listObjectVersionsReturnsOnCall map[int]struct {
+		result1 *s3.ListObjectVersionsOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeS3Client) DeleteObjects(arg1 context.Context, arg2 *s3.DeleteObjectsInput, arg3 ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	fake.deleteObjectsMutex.Lock()
+	ret, specificReturn := fake.deleteObjectsReturnsOnCall[len(fake.deleteObjectsArgsForCall)]
+	fake.deleteObjectsArgsForCall = append(fake.deleteObjectsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *s3.DeleteObjectsInput
+		arg3 []func(*s3.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteObjectsStub
+	fakeReturns := fake.deleteObjectsReturns
+	fake.recordInvocation("DeleteObjects", []interface{}{arg1, arg2, arg3})
+	fake.deleteObjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeS3Client) DeleteObjectsCallCount() int {
+	fake.deleteObjectsMutex.RLock()
+	defer fake.deleteObjectsMutex.RUnlock()
+	return len(fake.deleteObjectsArgsForCall)
+}
+
+func (fake *FakeS3Client) DeleteObjectsCalls(stub func(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)) {
+	fake.deleteObjectsMutex.Lock()
+	defer fake.deleteObjectsMutex.Unlock()
+	fake.DeleteObjectsStub = stub
+}
+
+func (fake *FakeS3Client) DeleteObjectsArgsForCall(i int) (context.Context, *s3.DeleteObjectsInput, []func(*s3.Options)) {
+	fake.deleteObjectsMutex.RLock()
+	defer fake.deleteObjectsMutex.RUnlock()
+	argsForCall := fake.deleteObjectsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeS3Client) DeleteObjectsReturns(result1 *s3.DeleteObjectsOutput, result2 error) {
+	fake.deleteObjectsMutex.Lock()
+	defer fake.deleteObjectsMutex.Unlock()
+	fake.DeleteObjectsStub = nil
+	fake.deleteObjectsReturns = struct {
+		result1 *s3.DeleteObjectsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) DeleteObjectsReturnsOnCall(i int, result1 *s3.DeleteObjectsOutput, result2 error) {
+	fake.deleteObjectsMutex.Lock()
+	defer fake.deleteObjectsMutex.Unlock()
+	fake.DeleteObjectsStub = nil
+	if fake.deleteObjectsReturnsOnCall == nil {
+		fake.deleteObjectsReturnsOnCall = make(map[int]struct {
+			result1 *s3.DeleteObjectsOutput
+			result2 error
+		})
+	}
+	fake.deleteObjectsReturnsOnCall[i] = struct {
+		result1 *s3.DeleteObjectsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) HeadBucket(arg1 context.Context, arg2 *s3.HeadBucketInput, arg3 ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	fake.headBucketMutex.Lock()
+	ret, specificReturn := fake.headBucketReturnsOnCall[len(fake.headBucketArgsForCall)]
+	fake.headBucketArgsForCall = append(fake.headBucketArgsForCall, struct {
+		arg1 context.Context
+		arg2 *s3.HeadBucketInput
+		arg3 []func(*s3.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.HeadBucketStub
+	fakeReturns := fake.headBucketReturns
+	fake.recordInvocation("HeadBucket", []interface{}{arg1, arg2, arg3})
+	fake.headBucketMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeS3Client) HeadBucketCallCount() int {
+	fake.headBucketMutex.RLock()
+	defer fake.headBucketMutex.RUnlock()
+	return len(fake.headBucketArgsForCall)
+}
+
+func (fake *FakeS3Client) HeadBucketCalls(stub func(context.Context, *s3.HeadBucketInput, ...func(*s3.Options)) (*s3.HeadBucketOutput, error)) {
+	fake.headBucketMutex.Lock()
+	defer fake.headBucketMutex.Unlock()
+	fake.HeadBucketStub = stub
+}
+
+func (fake *FakeS3Client) HeadBucketArgsForCall(i int) (context.Context, *s3.HeadBucketInput, []func(*s3.Options)) {
+	fake.headBucketMutex.RLock()
+	defer fake.headBucketMutex.RUnlock()
+	argsForCall := fake.headBucketArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeS3Client) HeadBucketReturns(result1 *s3.HeadBucketOutput, result2 error) {
+	fake.headBucketMutex.Lock()
+	defer fake.headBucketMutex.Unlock()
+	fake.HeadBucketStub = nil
+	fake.headBucketReturns = struct {
+		result1 *s3.HeadBucketOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) HeadBucketReturnsOnCall(i int, result1 *s3.HeadBucketOutput, result2 error) {
+	fake.headBucketMutex.Lock()
+	defer fake.headBucketMutex.Unlock()
+	fake.HeadBucketStub = nil
+	if fake.headBucketReturnsOnCall == nil {
+		fake.headBucketReturnsOnCall = make(map[int]struct {
+			result1 *s3.HeadBucketOutput
+			result2 error
+		})
+	}
+	fake.headBucketReturnsOnCall[i] = struct {
+		result1 *s3.HeadBucketOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) ListObjectVersions(arg1 context.Context, arg2 *s3.ListObjectVersionsInput, arg3 ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+	fake.listObjectVersionsMutex.Lock()
+	ret, specificReturn := fake.listObjectVersionsReturnsOnCall[len(fake.listObjectVersionsArgsForCall)]
+	fake.listObjectVersionsArgsForCall = append(fake.listObjectVersionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *s3.ListObjectVersionsInput
+		arg3 []func(*s3.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ListObjectVersionsStub
+	fakeReturns := fake.listObjectVersionsReturns
+	fake.recordInvocation("ListObjectVersions", []interface{}{arg1, arg2, arg3})
+	fake.listObjectVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeS3Client) ListObjectVersionsCallCount() int {
+	fake.listObjectVersionsMutex.RLock()
+	defer fake.listObjectVersionsMutex.RUnlock()
+	return len(fake.listObjectVersionsArgsForCall)
+}
+
+func (fake *FakeS3Client) ListObjectVersionsCalls(stub func(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)) {
+	fake.listObjectVersionsMutex.Lock()
+	defer fake.listObjectVersionsMutex.Unlock()
+	fake.ListObjectVersionsStub = stub
+}
+
+func (fake *FakeS3Client) ListObjectVersionsArgsForCall(i int) (context.Context, *s3.ListObjectVersionsInput, []func(*s3.Options)) {
+	fake.listObjectVersionsMutex.RLock()
+	defer fake.listObjectVersionsMutex.RUnlock()
+	argsForCall := fake.listObjectVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeS3Client) ListObjectVersionsReturns(result1 *s3.ListObjectVersionsOutput, result2 error) {
+	fake.listObjectVersionsMutex.Lock()
+	defer fake.listObjectVersionsMutex.Unlock()
+	fake.ListObjectVersionsStub = nil
+	fake.listObjectVersionsReturns = struct {
+		result1 *s3.ListObjectVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) ListObjectVersionsReturnsOnCall(i int, result1 *s3.ListObjectVersionsOutput, result2 error) {
+	fake.listObjectVersionsMutex.Lock()
+	defer fake.listObjectVersionsMutex.Unlock()
+	fake.ListObjectVersionsStub = nil
+	if fake.listObjectVersionsReturnsOnCall == nil {
+		fake.listObjectVersionsReturnsOnCall = make(map[int]struct {
+			result1 *s3.ListObjectVersionsOutput
+			result2 error
+		})
+	}
+	fake.listObjectVersionsReturnsOnCall[i] = struct {
+		result1 *s3.ListObjectVersionsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeS3Client) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteObjectsMutex.RLock()
+	defer fake.deleteObjectsMutex.RUnlock()
+	fake.headBucketMutex.RLock()
+	defer fake.headBucketMutex.RUnlock()
+	fake.listObjectVersionsMutex.RLock()
+	defer fake.listObjectVersionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeS3Client) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbs3.S3Client = new(FakeS3Client)
@@ -0,0 +1,102 @@
+package csbs3
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	BucketKey                    = "bucket"
+	BypassGovernanceRetentionKey = "bypass_governance_retention"
+
+	defaultDeletionTimeout = 30 * time.Minute
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbs3fakes/header.txt . S3Client
+type S3Client interface {
+	HeadBucket(context.Context, *s3.HeadBucketInput, ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	ListObjectVersions(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	DeleteObjects(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+}
+
+var _ S3Client = &s3.Client{}
+
+func ResourceS3Bucket() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			BucketKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the bucket to empty before it is deleted",
+			},
+			BypassGovernanceRetentionKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete object versions that are under Object Lock governance mode retention",
+			},
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(defaultDeletionTimeout),
+		},
+		CreateContext: setResourceID,
+		UpdateContext: setResourceID,
+		ReadContext:   ResourceS3BucketRead,
+		DeleteContext: ResourceS3BucketDelete,
+		Description:   "Empties an S3 bucket, including every object version and delete marker, before it is deleted",
+	}
+}
+
+func setResourceID(_ context.Context, data *schema.ResourceData, _ any) diag.Diagnostics {
+	data.SetId(data.Get(BucketKey).(string))
+	return nil
+}
+
+// ResourceS3BucketRead removes the resource from the state when the bucket no longer exists, as there is nothing left to empty
+func ResourceS3BucketRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(S3Config).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(data.Get(BucketKey).(string))})
+	var notFound *types.NotFound
+	switch {
+	case errors.As(err, &notFound):
+		tflog.Warn(ctx, "Removing S3 bucket housekeeping from the state because the bucket no longer exists", map[string]any{
+			"bucket": data.Get(BucketKey).(string),
+		})
+		data.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	default:
+		return setResourceID(ctx, data, config)
+	}
+}
+
+func ResourceS3BucketDelete(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(S3Config).GetClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d := emptyBucket(ctx, client, data.Get(BucketKey).(string), data.Get(BypassGovernanceRetentionKey).(bool))
+	if len(d) > 0 {
+		return d
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package csbs3_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-s3/csbs3"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-s3/csbs3/csbs3fakes"
+)
+
+var _ = Describe("ResourceS3Bucket", func() {
+	var (
+		client *csbs3fakes.FakeS3Client
+		config *csbs3fakes.FakeS3Config
+		data   *schema.ResourceData
+	)
+
+	BeforeEach(func() {
+		client = &csbs3fakes.FakeS3Client{}
+		client.ListObjectVersionsReturns(&s3.ListObjectVersionsOutput{}, nil)
+		client.DeleteObjectsReturns(&s3.DeleteObjectsOutput{}, nil)
+
+		config = &csbs3fakes.FakeS3Config{}
+		config.GetClientReturns(client, nil)
+
+		data = csbs3.ResourceS3Bucket().TestResourceData()
+		Expect(data.Set(csbs3.BucketKey, "csb-bucket")).To(Succeed())
+		Expect(data.Set(csbs3.AwsAccessKeyIDKey, "id")).To(Succeed())
+		Expect(data.Set(csbs3.AwsSecretAccessKeyKey, "key")).To(Succeed())
+	})
+
+	deletedObjects := func() (objects []string) {
+		for i := range client.DeleteObjectsCallCount() {
+			_, input, _ := client.DeleteObjectsArgsForCall(i)
+			for _, object := range input.Delete.Objects {
+				objects = append(objects, fmt.Sprintf("%s@%s", aws.ToString(object.Key), aws.ToString(object.VersionId)))
+			}
+		}
+		return objects
+	}
+
+	It("uses the credentials of the resource", func() {
+		Expect(data.Set(csbs3.AwsSessionTokenKey, "token")).To(Succeed())
+
+		Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+		Expect(config.GetClientCallCount()).To(Equal(1))
+		_, creds := config.GetClientArgsForCall(0)
+		Expect(creds).To(Equal(csbs3.Credentials{AccessKeyID: "id", SecretAccessKey: "key", SessionToken: "token"}))
+	})
+
+	It("does nothing when the bucket is empty", func() {
+		Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+		Expect(client.ListObjectVersionsCallCount()).To(Equal(1))
+		Expect(client.DeleteObjectsCallCount()).To(BeZero())
+	})
+
+	Context("the bucket has several pages of versions and delete markers", func() {
+		BeforeEach(func() {
+			client.ListObjectVersionsReturnsOnCall(0, &s3.ListObjectVersionsOutput{
+				Versions: []types.ObjectVersion{
+					{Key: aws.String("a"), VersionId: aws.String("1")},
+					{Key: aws.String("a"), VersionId: aws.String("2")},
+				},
+				DeleteMarkers: []types.DeleteMarkerEntry{
+					{Key: aws.String("b"), VersionId: aws.String("3")},
+				},
+				IsTruncated:         aws.Bool(true),
+				NextKeyMarker:       aws.String("b"),
+				NextVersionIdMarker: aws.String("3"),
+			}, nil)
+			client.ListObjectVersionsReturnsOnCall(1, &s3.ListObjectVersionsOutput{
+				Versions: []types.ObjectVersion{
+					{Key: aws.String("c"), VersionId: aws.String("null")},
+				},
+				IsTruncated: aws.Bool(false),
+			}, nil)
+		})
+
+		It("deletes every version and delete marker", func() {
+			Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+
+			Expect(client.ListObjectVersionsCallCount()).To(Equal(2))
+			_, input, _ := client.ListObjectVersionsArgsForCall(0)
+			Expect(aws.ToString(input.Bucket)).To(Equal("csb-bucket"))
+			Expect(input.KeyMarker).To(BeNil())
+			_, input, _ = client.ListObjectVersionsArgsForCall(1)
+			Expect(aws.ToString(input.KeyMarker)).To(Equal("b"))
+			Expect(aws.ToString(input.VersionIdMarker)).To(Equal("3"))
+
+			Expect(client.DeleteObjectsCallCount()).To(Equal(2))
+			Expect(deletedObjects()).To(Equal([]string{"a@1", "a@2", "b@3", "c@null"}))
+		})
+
+		It("does not bypass governance mode retention by default", func() {
+			Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+
+			_, input, _ := client.DeleteObjectsArgsForCall(0)
+			Expect(input.BypassGovernanceRetention).To(BeNil())
+			Expect(aws.ToBool(input.Delete.Quiet)).To(BeTrue())
+		})
+
+		It("bypasses governance mode retention when the flag is set", func() {
+			Expect(data.Set(csbs3.BypassGovernanceRetentionKey, true)).To(Succeed())
+			Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+
+			for i := range client.DeleteObjectsCallCount() {
+				_, input, _ := client.DeleteObjectsArgsForCall(i)
+				Expect(aws.ToBool(input.BypassGovernanceRetention)).To(BeTrue())
+			}
+		})
+
+		It("accumulates the errors of every key and carries on", func() {
+			client.DeleteObjectsReturnsOnCall(0, &s3.DeleteObjectsOutput{
+				Errors: []types.Error{
+					{Key: aws.String("a"), VersionId: aws.String("1"), Code: aws.String("AccessDenied"), Message: aws.String("Access Denied because object protected by object lock.")},
+					{Key: aws.String("b"), VersionId: aws.String("3"), Code: aws.String("InternalError"), Message: aws.String("We encountered an internal error.")},
+				},
+			}, nil)
+			client.DeleteObjectsReturnsOnCall(1, nil, fmt.Errorf("connection issues"))
+
+			d := csbs3.ResourceS3BucketDelete(context.TODO(), data, config)
+			Expect(d.HasError()).To(BeTrue())
+			Expect(d).To(HaveLen(3))
+			Expect(d[0].Summary).To(Equal(`object "a" version "1" was not deleted: Access Denied because object protected by object lock.`))
+			Expect(d[0].Detail).To(ContainSubstring("bypass_governance_retention"))
+			Expect(d[1].Summary).To(Equal(`object "b" version "3" was not deleted: We encountered an internal error.`))
+			Expect(d[1].Detail).To(Equal("InternalError"))
+			Expect(d[2].Summary).To(Equal("connection issues"))
+			Expect(d[2].Detail).To(Equal(`1 object versions of bucket "csb-bucket" were not deleted`))
+			Expect(client.DeleteObjectsCallCount()).To(Equal(2))
+		})
+
+		It("reports listing errors after the errors of the pages already listed", func() {
+			client.ListObjectVersionsReturnsOnCall(1, nil, fmt.Errorf("throttled"))
+			client.DeleteObjectsReturnsOnCall(0, nil, fmt.Errorf("connection issues"))
+
+			d := csbs3.ResourceS3BucketDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(2))
+			Expect(d[0].Summary).To(Equal("connection issues"))
+			Expect(d[1].Summary).To(Equal("throttled"))
+		})
+	})
+
+	It("stops on repeated markers", func() {
+		client.ListObjectVersionsReturns(&s3.ListObjectVersionsOutput{
+			Versions:            []types.ObjectVersion{{Key: aws.String("a"), VersionId: aws.String("1")}},
+			IsTruncated:         aws.Bool(true),
+			NextKeyMarker:       aws.String("a"),
+			NextVersionIdMarker: aws.String("1"),
+		}, nil)
+
+		Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+		Expect(client.ListObjectVersionsCallCount()).To(Equal(2))
+	})
+
+	It("succeeds when the bucket no longer exists", func() {
+		client.ListObjectVersionsReturns(nil, &types.NoSuchBucket{})
+
+		Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(BeNil())
+		Expect(client.DeleteObjectsCallCount()).To(BeZero())
+	})
+
+	It("reports client creation errors", func() {
+		config.GetClientReturns(nil, fmt.Errorf("invalid region"))
+
+		Expect(csbs3.ResourceS3BucketDelete(context.TODO(), data, config)).To(Equal(diag.Errorf("invalid region")))
+	})
+
+	Describe("read", func() {
+		It("keeps the resource while the bucket exists", func() {
+			client.HeadBucketReturns(&s3.HeadBucketOutput{}, nil)
+
+			Expect(csbs3.ResourceS3BucketRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal("csb-bucket"))
+		})
+
+		It("removes the resource from the state when the bucket no longer exists", func() {
+			data.SetId("csb-bucket")
+			client.HeadBucketReturns(nil, &types.NotFound{})
+
+			Expect(csbs3.ResourceS3BucketRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(BeEmpty())
+		})
+
+		It("reports other errors", func() {
+			client.HeadBucketReturns(nil, fmt.Errorf("forbidden"))
+
+			Expect(csbs3.ResourceS3BucketRead(context.TODO(), data, config)).To(Equal(diag.Errorf("forbidden")))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package csbs3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+//counterfeiter:generate -header csbs3fakes/header.txt . S3Config
+type S3Config interface {
+	GetClient(ctx context.Context, creds Credentials) (S3Client, error)
+}
+
+type s3Settings struct {
+	region            string
+	customEndpointURL string
+}
+
+// Fail fast if the interface is not implemented
+var _ S3Config = &s3Settings{}
+
+func (s *s3Settings) GetClient(ctx context.Context, creds Credentials) (S3Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(s.region),
+		config.WithCredentialsProvider(
+			aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(
+					creds.AccessKeyID,
+					creds.SecretAccessKey,
+					creds.SessionToken,
+				),
+			),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// For testing we use a custom endpoint, which usually does not support virtual hosted buckets
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if s.customEndpointURL != "" {
+			o.BaseEndpoint = aws.String(s.customEndpointURL)
+			o.UsePathStyle = true
+		}
+	}), nil
+}
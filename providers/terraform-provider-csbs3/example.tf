@@ -0,0 +1,22 @@
+# Run "make init" to perform "terraform init"
+# The easiest way to get an S3 API is: docker run -p 4566:4566 -t localstack/localstack
+
+terraform {
+  required_providers {
+    csbs3 = {
+      source  = "cloudfoundry.org/cloud-service-broker/csbs3"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "csbs3" {
+  region              = "us-west-2"
+  custom_endpoint_url = "http://localhost:4566"
+}
+
+resource "csbs3_bucket" "housekeeping" {
+  bucket            = "csb-46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  access_key_id     = "FAKE-access-key-id"
+  secret_access_key = "FAKE-secret-access-key"
+}
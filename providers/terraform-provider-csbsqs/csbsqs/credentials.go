@@ -0,0 +1,50 @@
+package csbsqs
+
+import (
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	AwsAccessKeyIDKey     = "access_key_id"
+	AwsSecretAccessKeyKey = "secret_access_key"
+	AwsSessionTokenKey    = "session_token"
+)
+
+// Credentials are the static credentials used to connect to SQS and S3
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// withCredentialsSchema adds the credentials used to connect to SQS and S3 to the schema of a resource
+func withCredentialsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	maps.Copy(s, map[string]*schema.Schema{
+		AwsAccessKeyIDKey: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		AwsSecretAccessKeyKey: {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		AwsSessionTokenKey: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Session token for temporary credentials",
+		},
+	})
+	return s
+}
+
+func credentialsFromResourceData(data *schema.ResourceData) Credentials {
+	return Credentials{
+		AccessKeyID:     data.Get(AwsAccessKeyIDKey).(string),
+		SecretAccessKey: data.Get(AwsSecretAccessKeyKey).(string),
+		SessionToken:    data.Get(AwsSessionTokenKey).(string),
+	}
+}
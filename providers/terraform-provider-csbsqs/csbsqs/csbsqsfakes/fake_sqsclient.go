@@ -0,0 +1,290 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbsqsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-sqs/csbsqs"
+)
+
+type FakeSQSClient struct {
+	DeleteMessageBatchStub        func(context.Context, *sqs.DeleteMessageBatchInput, ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+	deleteMessageBatchMutex       sync.RWMutex
+	deleteMessageBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 *sqs.DeleteMessageBatchInput
+		arg3 []func(*sqs.Options)
+	}
+	deleteMessageBatchReturns struct {
+		result1 *sqs.DeleteMessageBatchOutput
+		result2 error
+	}
+	deleteMessageBatchReturnsOnCall map[int]struct {
+		result1 *sqs.DeleteMessageBatchOutput
+		result2 error
+	}
+	GetQueueAttributesStub        func(context.Context, *sqs.GetQueueAttributesInput, ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	getQueueAttributesMutex       sync.RWMutex
+	getQueueAttributesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *sqs.GetQueueAttributesInput
+		arg3 []func(*sqs.Options)
+	}
+	getQueueAttributesReturns struct {
+		result1 *sqs.GetQueueAttributesOutput
+		result2 error
+	}
+	getQueueAttributesReturnsOnCall map[int]struct {
+		result1 *sqs.GetQueueAttributesOutput
+		result2 error
+	}
+	ReceiveMessageStub        func(context.Context, *sqs.ReceiveMessageInput, ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	receiveMessageMutex       sync.RWMutex
+	receiveMessageArgsForCall []struct {
+		arg1 context.Context
+		arg2 *sqs.ReceiveMessageInput
+		arg3 []func(*sqs.Options)
+	}
+	receiveMessageReturns struct {
+		result1 *sqs.ReceiveMessageOutput
+		result2 error
+	}
+	receiveMessageReturnsOnCall map[int]struct {
+		result1 *sqs.ReceiveMessageOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatch(arg1 context.Context, arg2 *sqs.DeleteMessageBatchInput, arg3 ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+	fake.deleteMessageBatchMutex.Lock()
+	ret, specificReturn := fake.deleteMessageBatchReturnsOnCall[len(fake.deleteMessageBatchArgsForCall)]
+	fake.deleteMessageBatchArgsForCall = append(fake.deleteMessageBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 *sqs.DeleteMessageBatchInput
+		arg3 []func(*sqs.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteMessageBatchStub
+	fakeReturns := fake.deleteMessageBatchReturns
+	fake.recordInvocation("DeleteMessageBatch", []interface{}{arg1, arg2, arg3})
+	fake.deleteMessageBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatchCallCount() int {
+	fake.deleteMessageBatchMutex.RLock()
+	defer fake.deleteMessageBatchMutex.RUnlock()
+	return len(fake.deleteMessageBatchArgsForCall)
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatchCalls(stub func(context.Context, *sqs.DeleteMessageBatchInput, ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)) {
+	fake.deleteMessageBatchMutex.Lock()
+	defer fake.deleteMessageBatchMutex.Unlock()
+	fake.DeleteMessageBatchStub = stub
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatchArgsForCall(i int) (context.Context, *sqs.DeleteMessageBatchInput, []func(*sqs.Options)) {
+	fake.deleteMessageBatchMutex.RLock()
+	defer fake.deleteMessageBatchMutex.RUnlock()
+	argsForCall := fake.deleteMessageBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatchReturns(result1 *sqs.DeleteMessageBatchOutput, result2 error) {
+	fake.deleteMessageBatchMutex.Lock()
+	defer fake.deleteMessageBatchMutex.Unlock()
+	fake.DeleteMessageBatchStub = nil
+	fake.deleteMessageBatchReturns = struct {
+		result1 *sqs.DeleteMessageBatchOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) DeleteMessageBatchReturnsOnCall(i int, result1 *sqs.DeleteMessageBatchOutput, result2 error) {
+	fake.deleteMessageBatchMutex.Lock()
+	defer fake.deleteMessageBatchMutex.Unlock()
+	fake.DeleteMessageBatchStub = nil
+	if fake.deleteMessageBatchReturnsOnCall == nil {
+		fake.deleteMessageBatchReturnsOnCall = make(map[int]struct {
+			result1 *sqs.DeleteMessageBatchOutput
+			result2 error
+		})
+	}
+	fake.deleteMessageBatchReturnsOnCall[i] = struct {
+		result1 *sqs.DeleteMessageBatchOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) GetQueueAttributes(arg1 context.Context, arg2 *sqs.GetQueueAttributesInput, arg3 ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	fake.getQueueAttributesMutex.Lock()
+	ret, specificReturn := fake.getQueueAttributesReturnsOnCall[len(fake.getQueueAttributesArgsForCall)]
+	fake.getQueueAttributesArgsForCall = append(fake.getQueueAttributesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *sqs.GetQueueAttributesInput
+		arg3 []func(*sqs.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.GetQueueAttributesStub
+	fakeReturns := fake.getQueueAttributesReturns
+	fake.recordInvocation("GetQueueAttributes", []interface{}{arg1, arg2, arg3})
+	fake.getQueueAttributesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSQSClient) GetQueueAttributesCallCount() int {
+	fake.getQueueAttributesMutex.RLock()
+	defer fake.getQueueAttributesMutex.RUnlock()
+	return len(fake.getQueueAttributesArgsForCall)
+}
+
+func (fake *FakeSQSClient) GetQueueAttributesCalls(stub func(context.Context, *sqs.GetQueueAttributesInput, ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)) {
+	fake.getQueueAttributesMutex.Lock()
+	defer fake.getQueueAttributesMutex.Unlock()
+	fake.GetQueueAttributesStub = stub
+}
+
+func (fake *FakeSQSClient) GetQueueAttributesArgsForCall(i int) (context.Context, *sqs.GetQueueAttributesInput, []func(*sqs.Options)) {
+	fake.getQueueAttributesMutex.RLock()
+	defer fake.getQueueAttributesMutex.RUnlock()
+	argsForCall := fake.getQueueAttributesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSQSClient) GetQueueAttributesReturns(result1 *sqs.GetQueueAttributesOutput, result2 error) {
+	fake.getQueueAttributesMutex.Lock()
+	defer fake.getQueueAttributesMutex.Unlock()
+	fake.GetQueueAttributesStub = nil
+	fake.getQueueAttributesReturns = struct {
+		result1 *sqs.GetQueueAttributesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) GetQueueAttributesReturnsOnCall(i int, result1 *sqs.GetQueueAttributesOutput, result2 error) {
+	fake.getQueueAttributesMutex.Lock()
+	defer fake.getQueueAttributesMutex.Unlock()
+	fake.GetQueueAttributesStub = nil
+	if fake.getQueueAttributesReturnsOnCall == nil {
+		fake.getQueueAttributesReturnsOnCall = make(map[int]struct {
+			result1 *sqs.GetQueueAttributesOutput
+			result2 error
+		})
+	}
+	fake.getQueueAttributesReturnsOnCall[i] = struct {
+		result1 *sqs.GetQueueAttributesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) ReceiveMessage(arg1 context.Context, arg2 *sqs.ReceiveMessageInput, arg3 ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	fake.receiveMessageMutex.Lock()
+	ret, specificReturn := fake.receiveMessageReturnsOnCall[len(fake.receiveMessageArgsForCall)]
+	fake.receiveMessageArgsForCall = append(fake.receiveMessageArgsForCall, struct {
+		arg1 context.Context
+		arg2 *sqs.ReceiveMessageInput
+		arg3 []func(*sqs.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ReceiveMessageStub
+	fakeReturns := fake.receiveMessageReturns
+	fake.recordInvocation("ReceiveMessage", []interface{}{arg1, arg2, arg3})
+	fake.receiveMessageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSQSClient) ReceiveMessageCallCount() int {
+	fake.receiveMessageMutex.RLock()
+	defer fake.receiveMessageMutex.RUnlock()
+	return len(fake.receiveMessageArgsForCall)
+}
+
+func (fake *FakeSQSClient) ReceiveMessageCalls(stub func(context.Context, *sqs.ReceiveMessageInput, ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)) {
+	fake.receiveMessageMutex.Lock()
+	defer fake.receiveMessageMutex.Unlock()
+	fake.ReceiveMessageStub = stub
+}
+
+func (fake *FakeSQSClient) ReceiveMessageArgsForCall(i int) (context.Context, *sqs.ReceiveMessageInput, []func(*sqs.Options)) {
+	fake.receiveMessageMutex.RLock()
+	defer fake.receiveMessageMutex.RUnlock()
+	argsForCall := fake.receiveMessageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSQSClient) ReceiveMessageReturns(result1 *sqs.ReceiveMessageOutput, result2 error) {
+	fake.receiveMessageMutex.Lock()
+	defer fake.receiveMessageMutex.Unlock()
+	fake.ReceiveMessageStub = nil
+	fake.receiveMessageReturns = struct {
+		result1 *sqs.ReceiveMessageOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) ReceiveMessageReturnsOnCall(i int, result1 *sqs.ReceiveMessageOutput, result2 error) {
+	fake.receiveMessageMutex.Lock()
+	defer fake.receiveMessageMutex.Unlock()
+	fake.ReceiveMessageStub = nil
+	if fake.receiveMessageReturnsOnCall == nil {
+		fake.receiveMessageReturnsOnCall = make(map[int]struct {
+			result1 *sqs.ReceiveMessageOutput
+			result2 error
+		})
+	}
+	fake.receiveMessageReturnsOnCall[i] = struct {
+		result1 *sqs.ReceiveMessageOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMessageBatchMutex.RLock()
+	defer fake.deleteMessageBatchMutex.RUnlock()
+	fake.getQueueAttributesMutex.RLock()
+	defer fake.getQueueAttributesMutex.RUnlock()
+	fake.receiveMessageMutex.RLock()
+	defer fake.receiveMessageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSQSClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbsqs.SQSClient = new(FakeSQSClient)
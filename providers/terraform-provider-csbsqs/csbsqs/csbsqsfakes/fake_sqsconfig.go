@@ -0,0 +1,202 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+//
+//lint:file-ignore ST1000 auto-generated
+package csbsqsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-sqs/csbsqs"
+)
+
+type FakeSQSConfig struct {
+	GetS3ClientStub        func(context.Context, csbsqs.Credentials) (csbsqs.S3Client, error)
+	getS3ClientMutex       sync.RWMutex
+	getS3ClientArgsForCall []struct {
+		arg1 context.Context
+		arg2 csbsqs.Credentials
+	}
+	getS3ClientReturns struct {
+		result1 csbsqs.S3Client
+		result2 error
+	}
+	getS3ClientReturnsOnCall map[int]struct {
+		result1 csbsqs.S3Client
+		result2 error
+	}
+	GetSQSClientStub        func(context.Context, csbsqs.Credentials) (csbsqs.SQSClient, error)
+	getSQSClientMutex       sync.RWMutex
+	getSQSClientArgsForCall []struct {
+		arg1 context.Context
+		arg2 csbsqs.Credentials
+	}
+	getSQSClientReturns struct {
+		result1 csbsqs.SQSClient
+		result2 error
+	}
+	getSQSClientReturnsOnCall map[int]struct {
+		result1 csbsqs.SQSClient
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSQSConfig) GetS3Client(arg1 context.Context, arg2 csbsqs.Credentials) (csbsqs.S3Client, error) {
+	fake.getS3ClientMutex.Lock()
+	ret, specificReturn := fake.getS3ClientReturnsOnCall[len(fake.getS3ClientArgsForCall)]
+	fake.getS3ClientArgsForCall = append(fake.getS3ClientArgsForCall, struct {
+		arg1 context.Context
+		arg2 csbsqs.Credentials
+	}{arg1, arg2})
+	stub := fake.GetS3ClientStub
+	fakeReturns := fake.getS3ClientReturns
+	fake.recordInvocation("GetS3Client", []interface{}{arg1, arg2})
+	fake.getS3ClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSQSConfig) GetS3ClientCallCount() int {
+	fake.getS3ClientMutex.RLock()
+	defer fake.getS3ClientMutex.RUnlock()
+	return len(fake.getS3ClientArgsForCall)
+}
+
+func (fake *FakeSQSConfig) GetS3ClientCalls(stub func(context.Context, csbsqs.Credentials) (csbsqs.S3Client, error)) {
+	fake.getS3ClientMutex.Lock()
+	defer fake.getS3ClientMutex.Unlock()
+	fake.GetS3ClientStub = stub
+}
+
+func (fake *FakeSQSConfig) GetS3ClientArgsForCall(i int) (context.Context, csbsqs.Credentials) {
+	fake.getS3ClientMutex.RLock()
+	defer fake.getS3ClientMutex.RUnlock()
+	argsForCall := fake.getS3ClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSQSConfig) GetS3ClientReturns(result1 csbsqs.S3Client, result2 error) {
+	fake.getS3ClientMutex.Lock()
+	defer fake.getS3ClientMutex.Unlock()
+	fake.GetS3ClientStub = nil
+	fake.getS3ClientReturns = struct {
+		result1 csbsqs.S3Client
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSConfig) GetS3ClientReturnsOnCall(i int, result1 csbsqs.S3Client, result2 error) {
+	fake.getS3ClientMutex.Lock()
+	defer fake.getS3ClientMutex.Unlock()
+	fake.GetS3ClientStub = nil
+	if fake.getS3ClientReturnsOnCall == nil {
+		fake.getS3ClientReturnsOnCall = make(map[int]struct {
+			result1 csbsqs.S3Client
+			result2 error
+		})
+	}
+	fake.getS3ClientReturnsOnCall[i] = struct {
+		result1 csbsqs.S3Client
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSConfig) GetSQSClient(arg1 context.Context, arg2 csbsqs.Credentials) (csbsqs.SQSClient, error) {
+	fake.getSQSClientMutex.Lock()
+	ret, specificReturn := fake.getSQSClientReturnsOnCall[len(fake.getSQSClientArgsForCall)]
+	fake.getSQSClientArgsForCall = append(fake.getSQSClientArgsForCall, struct {
+		arg1 context.Context
+		arg2 csbsqs.Credentials
+	}{arg1, arg2})
+	stub := fake.GetSQSClientStub
+	fakeReturns := fake.getSQSClientReturns
+	fake.recordInvocation("GetSQSClient", []interface{}{arg1, arg2})
+	fake.getSQSClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSQSConfig) GetSQSClientCallCount() int {
+	fake.getSQSClientMutex.RLock()
+	defer fake.getSQSClientMutex.RUnlock()
+	return len(fake.getSQSClientArgsForCall)
+}
+
+func (fake *FakeSQSConfig) GetSQSClientCalls(stub func(context.Context, csbsqs.Credentials) (csbsqs.SQSClient, error)) {
+	fake.getSQSClientMutex.Lock()
+	defer fake.getSQSClientMutex.Unlock()
+	fake.GetSQSClientStub = stub
+}
+
+func (fake *FakeSQSConfig) GetSQSClientArgsForCall(i int) (context.Context, csbsqs.Credentials) {
+	fake.getSQSClientMutex.RLock()
+	defer fake.getSQSClientMutex.RUnlock()
+	argsForCall := fake.getSQSClientArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSQSConfig) GetSQSClientReturns(result1 csbsqs.SQSClient, result2 error) {
+	fake.getSQSClientMutex.Lock()
+	defer fake.getSQSClientMutex.Unlock()
+	fake.GetSQSClientStub = nil
+	fake.getSQSClientReturns = struct {
+		result1 csbsqs.SQSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSConfig) GetSQSClientReturnsOnCall(i int, result1 csbsqs.SQSClient, result2 error) {
+	fake.getSQSClientMutex.Lock()
+	defer fake.getSQSClientMutex.Unlock()
+	fake.GetSQSClientStub = nil
+	if fake.getSQSClientReturnsOnCall == nil {
+		fake.getSQSClientReturnsOnCall = make(map[int]struct {
+			result1 csbsqs.SQSClient
+			result2 error
+		})
+	}
+	fake.getSQSClientReturnsOnCall[i] = struct {
+		result1 csbsqs.SQSClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSQSConfig) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getS3ClientMutex.RLock()
+	defer fake.getS3ClientMutex.RUnlock()
+	fake.getSQSClientMutex.RLock()
+	defer fake.getSQSClientMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSQSConfig) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ csbsqs.SQSConfig = new(FakeSQSConfig)
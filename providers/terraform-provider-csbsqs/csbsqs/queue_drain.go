@@ -0,0 +1,319 @@
+package csbsqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	maxReceiveBatch          = 10
+	receiveWaitTimeSeconds   = 1
+	receiveVisibilityTimeout = 60
+)
+
+type drainOptions struct {
+	interval time.Duration
+	deadline time.Time
+}
+
+// archivedMessage is a line of the archive
+type archivedMessage struct {
+	QueueURL          string                       `json:"queue_url"`
+	MessageID         string                       `json:"message_id"`
+	Body              string                       `json:"body"`
+	Attributes        map[string]string            `json:"attributes,omitempty"`
+	MessageAttributes map[string]archivedAttribute `json:"message_attributes,omitempty"`
+}
+
+type archivedAttribute struct {
+	DataType    string `json:"data_type"`
+	StringValue string `json:"string_value,omitempty"`
+	BinaryValue []byte `json:"binary_value,omitempty"`
+}
+
+// archive keeps the drained messages once every queue has been drained
+type archive interface {
+	// dir is where the messages are written while the queues are drained
+	dir() string
+	// check fails when the archive cannot be written under the name, before any message is received
+	check(ctx context.Context, name string) error
+	// keep archives the file under the name, and returns where it is kept
+	keep(ctx context.Context, file *os.File, name string) (string, error)
+}
+
+type localArchive struct {
+	path string
+}
+
+func (l localArchive) dir() string {
+	return l.path
+}
+
+// check has nothing to do, as the messages are written in the archive directory itself
+func (l localArchive) check(context.Context, string) error {
+	return nil
+}
+
+func (l localArchive) keep(_ context.Context, file *os.File, name string) (string, error) {
+	target := filepath.Join(l.path, name)
+	return target, os.Rename(file.Name(), target)
+}
+
+type s3Archive struct {
+	client S3Client
+	bucket string
+	prefix string
+}
+
+func (s s3Archive) dir() string {
+	return os.TempDir()
+}
+
+// check writes an empty object next to the archive, and deletes it again. This proves that the bucket exists and
+// can be written to, so that no message is deleted from a queue when it could not be uploaded. The archive key
+// itself is never written until the archive is complete, so an empty archive is never left behind.
+func (s s3Archive) check(ctx context.Context, name string) error {
+	key := s.prefix + name + ".check"
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   strings.NewReader(""),
+	})
+	if err != nil {
+		return fmt.Errorf("archive bucket %q cannot be written to: %w", s.bucket, err)
+	}
+
+	// The check has passed even when the empty object cannot be deleted, which its name tells apart from an archive
+	_, _ = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	return nil
+}
+
+func (s s3Archive) keep(ctx context.Context, file *os.File, name string) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	key := s.prefix + name
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        file,
+		ContentType: aws.String("application/x-ndjson"),
+	}); err != nil {
+		return "", err
+	}
+
+	_ = os.Remove(file.Name())
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+}
+
+// drainQueues receives every message of the queues, in order, and archives them as JSON lines. Nothing is
+// received when the archive cannot be written to. Messages are only deleted from a queue once they have been
+// written to a local file, and the file is kept when it cannot be archived, so that it can be recovered.
+func drainQueues(ctx context.Context, client SQSClient, store archive, queueURLs []string, opts drainOptions) (d diag.Diagnostics) {
+	name := fmt.Sprintf("%s-%d.jsonl", path.Base(queueURLs[0]), time.Now().Unix())
+	if err := store.check(ctx, name); err != nil {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: err.Error(), Detail: "no message was received from the queues"}}
+	}
+
+	file, err := os.CreateTemp(store.dir(), "csbsqs-*.jsonl")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer file.Close()
+
+	var count int
+	for _, queueURL := range queueURLs {
+		n, queueDiags := drainQueue(ctx, client, queueURL, file, opts)
+		count += n
+		d = append(d, queueDiags...)
+	}
+
+	if count == 0 {
+		_ = os.Remove(file.Name())
+		return d
+	}
+
+	location, err := store.keep(ctx, file, name)
+	if err != nil {
+		return append(d, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   fmt.Sprintf("%d drained messages could not be archived, and were left in %s", count, file.Name()),
+		})
+	}
+
+	return append(d, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("archived %d messages to %s", count, location),
+	})
+}
+
+// drainQueue receives the messages of a queue until it is empty, or the deadline is reached while
+// messages are still in flight or delayed, and returns the number of messages that were written to the file
+func drainQueue(ctx context.Context, client SQSClient, queueURL string, file *os.File, opts drainOptions) (count int, d diag.Diagnostics) {
+	encoder := json.NewEncoder(file)
+	seen := make(map[string]struct{})
+	for {
+		output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(queueURL),
+			MaxNumberOfMessages:         maxReceiveBatch,
+			WaitTimeSeconds:             receiveWaitTimeSeconds,
+			VisibilityTimeout:           receiveVisibilityTimeout,
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+			MessageAttributeNames:       []string{"All"},
+		})
+		if err != nil {
+			return count, append(d, queueError(queueURL, err))
+		}
+
+		if len(output.Messages) > 0 {
+			var received []types.Message
+			for _, message := range output.Messages {
+				// Delivery is at least once, so a message can be received twice
+				if _, ok := seen[aws.ToString(message.MessageId)]; !ok {
+					if err := encoder.Encode(archivedMessageOf(queueURL, message)); err != nil {
+						return count, append(d, queueError(queueURL, err))
+					}
+					seen[aws.ToString(message.MessageId)] = struct{}{}
+					count++
+				}
+				received = append(received, message)
+			}
+
+			// The messages must be on disk before they are deleted from the queue
+			if err := file.Sync(); err != nil {
+				return count, append(d, queueError(queueURL, err))
+			}
+			if err := deleteMessages(ctx, client, queueURL, received); err != nil {
+				return count, append(d, queueError(queueURL, err))
+			}
+			continue
+		}
+
+		// Receiving can return no messages while there are still some, so the queue is only empty when its counters say so
+		remaining, err := remainingMessages(ctx, client, queueURL)
+		switch {
+		case err != nil:
+			return count, append(d, queueError(queueURL, err))
+		case remaining.visible+remaining.notVisible+remaining.delayed == 0:
+			return count, d
+		case !time.Now().Add(opts.interval).Before(opts.deadline):
+			return count, append(d, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%d messages of queue %q were not archived", remaining.visible+remaining.notVisible+remaining.delayed, queueURL),
+				Detail: fmt.Sprintf("when the deletion timeout was reached, %d messages were visible, %d were in flight and %d were delayed",
+					remaining.visible, remaining.notVisible, remaining.delayed),
+			})
+		case remaining.visible == 0:
+			// In flight messages become visible again when their visibility timeout expires, unless the consumer deletes them
+			select {
+			case <-ctx.Done():
+				return count, append(d, queueError(queueURL, ctx.Err()))
+			case <-time.After(opts.interval):
+			}
+		}
+	}
+}
+
+func deleteMessages(ctx context.Context, client SQSClient, queueURL string, messages []types.Message) error {
+	entries := make([]types.DeleteMessageBatchRequestEntry, 0, len(messages))
+	for i, message := range messages {
+		entries = append(entries, types.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: message.ReceiptHandle,
+		})
+	}
+
+	output, err := client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{QueueUrl: aws.String(queueURL), Entries: entries})
+	if err != nil {
+		return err
+	}
+
+	// Messages that were not deleted are already archived, and are deleted with the queue
+	for _, failed := range output.Failed {
+		tflog.Warn(ctx, "Archived SQS message could not be deleted from the queue", map[string]any{
+			"queue_url": queueURL,
+			"code":      aws.ToString(failed.Code),
+			"message":   aws.ToString(failed.Message),
+		})
+	}
+	return nil
+}
+
+type remainingCounts struct {
+	visible    int
+	notVisible int
+	delayed    int
+}
+
+func remainingMessages(ctx context.Context, client SQSClient, queueURL string) (remainingCounts, error) {
+	output, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameApproximateNumberOfMessages,
+			types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+			types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+		},
+	})
+	if err != nil {
+		return remainingCounts{}, err
+	}
+
+	var counts remainingCounts
+	for name, count := range map[types.QueueAttributeName]*int{
+		types.QueueAttributeNameApproximateNumberOfMessages:           &counts.visible,
+		types.QueueAttributeNameApproximateNumberOfMessagesNotVisible: &counts.notVisible,
+		types.QueueAttributeNameApproximateNumberOfMessagesDelayed:    &counts.delayed,
+	} {
+		if value, ok := output.Attributes[string(name)]; ok {
+			if *count, err = strconv.Atoi(value); err != nil {
+				return remainingCounts{}, fmt.Errorf("invalid value %q for queue attribute %s: %w", value, name, err)
+			}
+		}
+	}
+	return counts, nil
+}
+
+func archivedMessageOf(queueURL string, message types.Message) archivedMessage {
+	archived := archivedMessage{
+		QueueURL:   queueURL,
+		MessageID:  aws.ToString(message.MessageId),
+		Body:       aws.ToString(message.Body),
+		Attributes: message.Attributes,
+	}
+	for name, attribute := range message.MessageAttributes {
+		if archived.MessageAttributes == nil {
+			archived.MessageAttributes = make(map[string]archivedAttribute, len(message.MessageAttributes))
+		}
+		archived.MessageAttributes[name] = archivedAttribute{
+			DataType:    aws.ToString(attribute.DataType),
+			StringValue: aws.ToString(attribute.StringValue),
+			BinaryValue: attribute.BinaryValue,
+		}
+	}
+	return archived
+}
+
+func queueError(queueURL string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+		Detail:   fmt.Sprintf("queue %q was not completely drained", queueURL),
+	}
+}
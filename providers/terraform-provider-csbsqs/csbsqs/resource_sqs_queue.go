@@ -0,0 +1,178 @@
+package csbsqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	QueueURLKey          = "queue_url"
+	DLQURLKey            = "dlq_url"
+	ArchiveS3BucketKey   = "archive_s3_bucket"
+	ArchiveS3PrefixKey   = "archive_s3_prefix"
+	ArchivePathKey       = "archive_path"
+	DrainPollIntervalKey = "drain_poll_interval"
+
+	defaultDrainPollInterval = 5 * time.Second
+	defaultDeletionTimeout   = 10 * time.Minute
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate -header csbsqsfakes/header.txt . SQSClient
+type SQSClient interface {
+	ReceiveMessage(context.Context, *sqs.ReceiveMessageInput, ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessageBatch(context.Context, *sqs.DeleteMessageBatchInput, ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+	GetQueueAttributes(context.Context, *sqs.GetQueueAttributesInput, ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+}
+
+var _ SQSClient = &sqs.Client{}
+
+//counterfeiter:generate -header csbsqsfakes/header.txt . S3Client
+type S3Client interface {
+	PutObject(context.Context, *s3.PutObjectInput, ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+var _ S3Client = &s3.Client{}
+
+func ResourceSQSQueue() *schema.Resource {
+	return &schema.Resource{
+		Schema: withCredentialsSchema(map[string]*schema.Schema{
+			QueueURLKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "URL of the queue to drain before it is deleted",
+			},
+			DLQURLKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "URL of the dead-letter queue of the queue, which is drained after the queue",
+			},
+			ArchiveS3BucketKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{ArchiveS3BucketKey, ArchivePathKey},
+				Description:  "S3 bucket that the drained messages are archived to",
+			},
+			ArchiveS3PrefixKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{ArchiveS3BucketKey},
+				Description:  "Prefix of the key of the archive in the S3 bucket",
+			},
+			ArchivePathKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{ArchiveS3BucketKey, ArchivePathKey},
+				Description:  "Local directory that the drained messages are archived to",
+			},
+			DrainPollIntervalKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDrainPollInterval.String(),
+				ValidateFunc: validateDrainPollInterval,
+				Description:  "Interval between checks for messages that are in flight or delayed while the queue is drained",
+			},
+		}),
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(defaultDeletionTimeout),
+		},
+		CreateContext: setResourceID,
+		UpdateContext: setResourceID,
+		ReadContext:   ResourceSQSQueueRead,
+		DeleteContext: ResourceSQSQueueDelete,
+		Description:   "Drains an SQS queue and its dead-letter queue, and archives their messages, before the queue is deleted",
+	}
+}
+
+func setResourceID(_ context.Context, data *schema.ResourceData, _ any) diag.Diagnostics {
+	data.SetId(data.Get(QueueURLKey).(string))
+	return nil
+}
+
+// ResourceSQSQueueRead removes the resource from the state when the queue no longer exists, as there is nothing left to drain
+func ResourceSQSQueueRead(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	client, err := config.(SQSConfig).GetSQSClient(ctx, credentialsFromResourceData(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(data.Get(QueueURLKey).(string)),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	var notFound *types.QueueDoesNotExist
+	switch {
+	case errors.As(err, &notFound):
+		tflog.Warn(ctx, "Removing SQS queue housekeeping from the state because the queue no longer exists", map[string]any{
+			"queue_url": data.Get(QueueURLKey).(string),
+		})
+		data.SetId("")
+		return nil
+	case err != nil:
+		return diag.FromErr(err)
+	default:
+		return setResourceID(ctx, data, config)
+	}
+}
+
+func ResourceSQSQueueDelete(ctx context.Context, data *schema.ResourceData, config any) diag.Diagnostics {
+	settings := config.(SQSConfig)
+	creds := credentialsFromResourceData(data)
+	client, err := settings.GetSQSClient(ctx, creds)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var store archive = localArchive{path: data.Get(ArchivePathKey).(string)}
+	if bucket := data.Get(ArchiveS3BucketKey).(string); bucket != "" {
+		s3Client, err := settings.GetS3Client(ctx, creds)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		store = s3Archive{client: s3Client, bucket: bucket, prefix: data.Get(ArchiveS3PrefixKey).(string)}
+	}
+
+	queueURLs := []string{data.Get(QueueURLKey).(string)}
+	if dlqURL := data.Get(DLQURLKey).(string); dlqURL != "" {
+		queueURLs = append(queueURLs, dlqURL)
+	}
+
+	// Terraform cancels the context at the delete timeout, so draining stops just before then to report what is left
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(data.Timeout(schema.TimeoutDelete))
+	}
+	// The schema defaults drain_poll_interval, and only accepts positive durations
+	interval, _ := time.ParseDuration(data.Get(DrainPollIntervalKey).(string))
+
+	d := drainQueues(ctx, client, store, queueURLs, drainOptions{interval: interval, deadline: deadline})
+	if len(d) > 0 {
+		return d
+	}
+	return nil
+}
+
+func validateDrainPollInterval(i any, key string) (warnings []string, errs []error) {
+	if interval, err := time.ParseDuration(i.(string)); err != nil || interval <= 0 {
+		errs = append(errs, fmt.Errorf("expected %q to be a positive duration, such as %s, got %q", key, defaultDrainPollInterval, i))
+	}
+	return warnings, errs
+}
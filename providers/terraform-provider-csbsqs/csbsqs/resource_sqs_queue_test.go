@@ -0,0 +1,346 @@
+package csbsqs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-sqs/csbsqs"
+	"github.com/cloudfoundry/csb-brokerpak-aws/terraform-provider-sqs/csbsqs/csbsqsfakes"
+)
+
+const (
+	queueURL = "https://sqs.us-west-2.amazonaws.com/123456789012/csb-queue"
+	dlqURL   = "https://sqs.us-west-2.amazonaws.com/123456789012/csb-queue-dlq"
+)
+
+var _ = Describe("ResourceSQSQueue", func() {
+	var (
+		client     *csbsqsfakes.FakeSQSClient
+		s3Client   *csbsqsfakes.FakeS3Client
+		config     *csbsqsfakes.FakeSQSConfig
+		data       *schema.ResourceData
+		archiveDir string
+		queues     map[string][]types.Message
+		counters   map[string]map[string]string
+	)
+
+	message := func(id, body string) types.Message {
+		return types.Message{
+			MessageId:     aws.String(id),
+			ReceiptHandle: aws.String("handle-" + id),
+			Body:          aws.String(body),
+			Attributes:    map[string]string{"SentTimestamp": "1700000000000"},
+		}
+	}
+
+	// archivedLines reads the archive that was written to the archive directory
+	archivedLines := func() (lines []map[string]any) {
+		entries, err := os.ReadDir(archiveDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Name()).To(MatchRegexp(`^csb-queue-\d+\.jsonl$`))
+
+		contents, err := os.ReadFile(filepath.Join(archiveDir, entries[0].Name()))
+		Expect(err).NotTo(HaveOccurred())
+		for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+			var decoded map[string]any
+			Expect(json.Unmarshal([]byte(line), &decoded)).To(Succeed())
+			lines = append(lines, decoded)
+		}
+		return lines
+	}
+
+	// receiveOnCall replaces a single receive, and leaves the others to the fake queues
+	receiveOnCall := func(call int, output *sqs.ReceiveMessageOutput, err error) {
+		receive := client.ReceiveMessageStub
+		client.ReceiveMessageStub = func(ctx context.Context, input *sqs.ReceiveMessageInput, opts ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			if client.ReceiveMessageCallCount() == call+1 {
+				return output, err
+			}
+			return receive(ctx, input, opts...)
+		}
+	}
+
+	BeforeEach(func() {
+		queues = map[string][]types.Message{}
+		counters = map[string]map[string]string{}
+
+		client = &csbsqsfakes.FakeSQSClient{}
+		// The fake queues hand out at most two messages per receive, and forget them once they are deleted
+		client.ReceiveMessageStub = func(_ context.Context, input *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			messages := queues[aws.ToString(input.QueueUrl)]
+			return &sqs.ReceiveMessageOutput{Messages: messages[:min(2, len(messages))]}, nil
+		}
+		client.DeleteMessageBatchStub = func(_ context.Context, input *sqs.DeleteMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+			url := aws.ToString(input.QueueUrl)
+			for _, entry := range input.Entries {
+				for i, message := range queues[url] {
+					if aws.ToString(message.ReceiptHandle) == aws.ToString(entry.ReceiptHandle) {
+						queues[url] = append(queues[url][:i], queues[url][i+1:]...)
+						break
+					}
+				}
+			}
+			return &sqs.DeleteMessageBatchOutput{}, nil
+		}
+		client.GetQueueAttributesStub = func(_ context.Context, input *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+			attributes := map[string]string{
+				"ApproximateNumberOfMessages":           fmt.Sprint(len(queues[aws.ToString(input.QueueUrl)])),
+				"ApproximateNumberOfMessagesNotVisible": "0",
+				"ApproximateNumberOfMessagesDelayed":    "0",
+			}
+			for name, value := range counters[aws.ToString(input.QueueUrl)] {
+				attributes[name] = value
+			}
+			return &sqs.GetQueueAttributesOutput{Attributes: attributes}, nil
+		}
+
+		s3Client = &csbsqsfakes.FakeS3Client{}
+		s3Client.PutObjectReturns(&s3.PutObjectOutput{}, nil)
+
+		config = &csbsqsfakes.FakeSQSConfig{}
+		config.GetSQSClientReturns(client, nil)
+		config.GetS3ClientReturns(s3Client, nil)
+
+		archiveDir = GinkgoT().TempDir()
+		data = csbsqs.ResourceSQSQueue().TestResourceData()
+		Expect(data.Set(csbsqs.QueueURLKey, queueURL)).To(Succeed())
+		Expect(data.Set(csbsqs.ArchivePathKey, archiveDir)).To(Succeed())
+		Expect(data.Set(csbsqs.DrainPollIntervalKey, "10ms")).To(Succeed())
+		Expect(data.Set(csbsqs.AwsAccessKeyIDKey, "id")).To(Succeed())
+		Expect(data.Set(csbsqs.AwsSecretAccessKeyKey, "key")).To(Succeed())
+	})
+
+	It("does not write an archive when the queue is empty", func() {
+		Expect(csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)).To(BeNil())
+		Expect(client.DeleteMessageBatchCallCount()).To(BeZero())
+		Expect(os.ReadDir(archiveDir)).To(BeEmpty())
+	})
+
+	It("archives the messages of the queue and then of the dead-letter queue", func() {
+		Expect(data.Set(csbsqs.DLQURLKey, dlqURL)).To(Succeed())
+		queues[queueURL] = []types.Message{message("1", "one"), message("2", "two"), message("3", "three")}
+		queues[dlqURL] = []types.Message{message("4", "four")}
+		queues[dlqURL][0].MessageAttributes = map[string]types.MessageAttributeValue{
+			"trace": {DataType: aws.String("String"), StringValue: aws.String("abc")},
+		}
+
+		d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Warning))
+		Expect(d[0].Summary).To(MatchRegexp(`^archived 4 messages to .*csb-queue-\d+\.jsonl$`))
+
+		lines := archivedLines()
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[0]).To(Equal(map[string]any{
+			"queue_url":  queueURL,
+			"message_id": "1",
+			"body":       "one",
+			"attributes": map[string]any{"SentTimestamp": "1700000000000"},
+		}))
+		Expect(lines[2]).To(HaveKeyWithValue("body", "three"))
+		Expect(lines[3]).To(HaveKeyWithValue("queue_url", dlqURL))
+		Expect(lines[3]).To(HaveKeyWithValue("message_attributes", map[string]any{
+			"trace": map[string]any{"data_type": "String", "string_value": "abc"},
+		}))
+
+		Expect(queues[queueURL]).To(BeEmpty())
+		Expect(queues[dlqURL]).To(BeEmpty())
+	})
+
+	It("requests every attribute of the messages", func() {
+		queues[queueURL] = []types.Message{message("1", "one")}
+
+		Expect(csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)).To(HaveLen(1))
+		_, input, _ := client.ReceiveMessageArgsForCall(0)
+		Expect(input.MaxNumberOfMessages).To(BeEquivalentTo(10))
+		Expect(input.MessageSystemAttributeNames).To(ConsistOf(types.MessageSystemAttributeNameAll))
+		Expect(input.MessageAttributeNames).To(ConsistOf("All"))
+	})
+
+	It("archives a message that is received twice once", func() {
+		queues[queueURL] = []types.Message{message("1", "one")}
+		receiveOnCall(0, &sqs.ReceiveMessageOutput{Messages: []types.Message{message("1", "one")}}, nil)
+
+		Expect(csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)).To(HaveLen(1))
+		Expect(archivedLines()).To(HaveLen(1))
+	})
+
+	It("keeps receiving while the queue reports visible messages", func() {
+		queues[queueURL] = []types.Message{message("1", "one")}
+		receiveOnCall(0, &sqs.ReceiveMessageOutput{}, nil)
+
+		Expect(csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)).To(HaveLen(1))
+		Expect(archivedLines()).To(HaveLen(1))
+	})
+
+	It("waits for messages that are in flight", func() {
+		counters[queueURL] = map[string]string{"ApproximateNumberOfMessagesNotVisible": "1"}
+		receive := client.ReceiveMessageStub
+		client.ReceiveMessageStub = func(ctx context.Context, input *sqs.ReceiveMessageInput, opts ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+			if client.GetQueueAttributesCallCount() == 2 && counters[queueURL] != nil {
+				// The consumer did not delete the message, which is visible again
+				delete(counters, queueURL)
+				queues[queueURL] = []types.Message{message("1", "one")}
+			}
+			return receive(ctx, input, opts...)
+		}
+
+		Expect(csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)).To(HaveLen(1))
+		Expect(client.GetQueueAttributesCallCount()).To(Equal(3))
+		Expect(archivedLines()).To(HaveLen(1))
+	})
+
+	It("warns about messages that are still in flight or delayed when the deadline is reached", func() {
+		counters[queueURL] = map[string]string{
+			"ApproximateNumberOfMessagesNotVisible": "2",
+			"ApproximateNumberOfMessagesDelayed":    "1",
+		}
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+
+		d := csbsqs.ResourceSQSQueueDelete(ctx, data, config)
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Warning))
+		Expect(d[0].Summary).To(Equal(fmt.Sprintf("3 messages of queue %q were not archived", queueURL)))
+		Expect(d[0].Detail).To(Equal("when the deletion timeout was reached, 0 messages were visible, 2 were in flight and 1 were delayed"))
+	})
+
+	It("fails and keeps the messages in the queue when they cannot be written", func() {
+		queues[queueURL] = []types.Message{message("1", "one")}
+		Expect(data.Set(csbsqs.ArchivePathKey, filepath.Join(archiveDir, "missing"))).To(Succeed())
+
+		d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+		Expect(d).To(HaveLen(1))
+		Expect(d[0].Severity).To(Equal(diag.Error))
+		Expect(client.ReceiveMessageCallCount()).To(BeZero())
+		Expect(queues[queueURL]).To(HaveLen(1))
+	})
+
+	It("stops draining a queue when receiving fails", func() {
+		Expect(data.Set(csbsqs.DLQURLKey, dlqURL)).To(Succeed())
+		queues[dlqURL] = []types.Message{message("1", "one")}
+		receiveOnCall(0, nil, errors.New("boom"))
+
+		d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+		Expect(d).To(HaveLen(2))
+		Expect(d[0].Severity).To(Equal(diag.Error))
+		Expect(d[0].Summary).To(Equal("boom"))
+		Expect(d[0].Detail).To(Equal(fmt.Sprintf("queue %q was not completely drained", queueURL)))
+		Expect(d[1].Severity).To(Equal(diag.Warning))
+		Expect(d[1].Summary).To(HavePrefix("archived 1 messages to "))
+	})
+
+	Context("the archive is in S3", func() {
+		var uploaded string
+
+		BeforeEach(func() {
+			Expect(data.Set(csbsqs.ArchivePathKey, "")).To(Succeed())
+			Expect(data.Set(csbsqs.ArchiveS3BucketKey, "csb-archive")).To(Succeed())
+			Expect(data.Set(csbsqs.ArchiveS3PrefixKey, "queues/")).To(Succeed())
+
+			s3Client.PutObjectStub = func(_ context.Context, input *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				contents, err := io.ReadAll(input.Body)
+				uploaded = string(contents)
+				return &s3.PutObjectOutput{}, err
+			}
+			queues[queueURL] = []types.Message{message("1", "one")}
+		})
+
+		It("uploads the messages", func() {
+			d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Summary).To(MatchRegexp(`^archived 1 messages to s3://csb-archive/queues/csb-queue-\d+\.jsonl$`))
+
+			Expect(s3Client.PutObjectCallCount()).To(Equal(2))
+			_, input, _ := s3Client.PutObjectArgsForCall(1)
+			Expect(aws.ToString(input.Bucket)).To(Equal("csb-archive"))
+			Expect(aws.ToString(input.Key)).To(MatchRegexp(`^queues/csb-queue-\d+\.jsonl$`))
+			Expect(uploaded).To(ContainSubstring(`"body":"one"`))
+		})
+
+		It("checks that the bucket can be written to with an object that it then deletes", func() {
+			csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+
+			_, check, _ := s3Client.PutObjectArgsForCall(0)
+			_, input, _ := s3Client.PutObjectArgsForCall(1)
+			Expect(aws.ToString(check.Bucket)).To(Equal("csb-archive"))
+			Expect(aws.ToString(check.Key)).To(Equal(aws.ToString(input.Key) + ".check"))
+
+			Expect(s3Client.DeleteObjectCallCount()).To(Equal(1))
+			_, deleted, _ := s3Client.DeleteObjectArgsForCall(0)
+			Expect(deleted.Key).To(Equal(check.Key))
+		})
+
+		It("does not receive any message when the bucket cannot be written to", func() {
+			s3Client.PutObjectStub = nil
+			s3Client.PutObjectReturns(nil, errors.New("access denied"))
+
+			d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Error))
+			Expect(d[0].Summary).To(Equal(`archive bucket "csb-archive" cannot be written to: access denied`))
+			Expect(client.ReceiveMessageCallCount()).To(BeZero())
+			Expect(client.DeleteMessageBatchCallCount()).To(BeZero())
+			Expect(queues[queueURL]).To(HaveLen(1))
+		})
+
+		It("fails and keeps the messages on disk when the upload fails", func() {
+			check := s3Client.PutObjectStub
+			s3Client.PutObjectStub = func(ctx context.Context, input *s3.PutObjectInput, opts ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+				if s3Client.PutObjectCallCount() == 1 {
+					return check(ctx, input, opts...)
+				}
+				return nil, errors.New("access denied")
+			}
+
+			d := csbsqs.ResourceSQSQueueDelete(context.TODO(), data, config)
+			Expect(d).To(HaveLen(1))
+			Expect(d[0].Severity).To(Equal(diag.Error))
+			Expect(d[0].Summary).To(Equal("access denied"))
+			Expect(d[0].Detail).To(HavePrefix("1 drained messages could not be archived, and were left in "))
+
+			path := strings.TrimPrefix(d[0].Detail, "1 drained messages could not be archived, and were left in ")
+			DeferCleanup(os.Remove, path)
+			Expect(os.ReadFile(path)).To(ContainSubstring(`"body":"one"`))
+
+			// Nothing was written where the archive would have been
+			for i := range s3Client.PutObjectCallCount() - 1 {
+				_, input, _ := s3Client.PutObjectArgsForCall(i)
+				Expect(aws.ToString(input.Key)).To(HaveSuffix(".check"))
+			}
+		})
+	})
+
+	Describe("read", func() {
+		It("removes the resource from the state when the queue no longer exists", func() {
+			data.SetId(queueURL)
+			client.GetQueueAttributesStub = nil
+			client.GetQueueAttributesReturns(nil, &types.QueueDoesNotExist{})
+
+			Expect(csbsqs.ResourceSQSQueueRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(BeEmpty())
+		})
+
+		It("keeps the resource when the queue exists", func() {
+			Expect(csbsqs.ResourceSQSQueueRead(context.TODO(), data, config)).To(BeNil())
+			Expect(data.Id()).To(Equal(queueURL))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package csbsqs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+//counterfeiter:generate -header csbsqsfakes/header.txt . SQSConfig
+type SQSConfig interface {
+	GetSQSClient(ctx context.Context, creds Credentials) (SQSClient, error)
+	GetS3Client(ctx context.Context, creds Credentials) (S3Client, error)
+}
+
+type sqsSettings struct {
+	region            string
+	customEndpointURL string
+}
+
+// Fail fast if the interface is not implemented
+var _ SQSConfig = &sqsSettings{}
+
+func (s *sqsSettings) GetSQSClient(ctx context.Context, creds Credentials) (SQSClient, error) {
+	cfg, err := s.awsConfig(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	return sqs.NewFromConfig(cfg), nil
+}
+
+func (s *sqsSettings) GetS3Client(ctx context.Context, creds Credentials) (S3Client, error) {
+	cfg, err := s.awsConfig(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// A custom endpoint usually does not support virtual hosted buckets
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = s.customEndpointURL != ""
+	}), nil
+}
+
+func (s *sqsSettings) awsConfig(ctx context.Context, creds Credentials) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(s.region),
+		config.WithCredentialsProvider(
+			aws.NewCredentialsCache(
+				credentials.NewStaticCredentialsProvider(
+					creds.AccessKeyID,
+					creds.SecretAccessKey,
+					creds.SessionToken,
+				),
+			),
+		),
+	)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	// For testing we use a custom endpoint
+	if s.customEndpointURL != "" {
+		cfg.BaseEndpoint = aws.String(s.customEndpointURL)
+	}
+	return cfg, nil
+}
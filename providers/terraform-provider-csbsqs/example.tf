@@ -0,0 +1,23 @@
+# Run "make init" to perform "terraform init"
+# The easiest way to get an SQS API is: docker run -p 4566:4566 -t localstack/localstack
+
+terraform {
+  required_providers {
+    csbsqs = {
+      source  = "cloudfoundry.org/cloud-service-broker/csbsqs"
+      version = "1.0.0"
+    }
+  }
+}
+
+provider "csbsqs" {
+  region              = "us-west-2"
+  custom_endpoint_url = "http://localhost:4566"
+}
+
+resource "csbsqs_queue" "housekeeping" {
+  queue_url         = "http://localhost:4566/000000000000/csb-46d6f6fb-c746-4488-8ed9-bc05bff03eb8"
+  archive_path      = "/tmp"
+  access_key_id     = "FAKE-access-key-id"
+  secret_access_key = "FAKE-secret-access-key"
+}